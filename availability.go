@@ -0,0 +1,333 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Busy time types of an availability, describing the time outside its opening hours.
+const (
+	BusyTypeBusy        = "BUSY"
+	BusyTypeUnavailable = "BUSY-UNAVAILABLE"
+	BusyTypeTentative   = "BUSY-TENTATIVE"
+)
+
+// Availability is a VAVAILABILITY component (RFC 7953), describing when someone or something is
+// available during a period of time.
+type Availability struct {
+	// Schedule contains the opening hours during which the component is available, given by its
+	// AVAILABLE sub-components.
+	Schedule ZonedSchedule
+
+	// Start and End delimit the period during which the component applies. A zero Start or End
+	// leaves the period unbounded on that side.
+	Start time.Time
+	End   time.Time
+
+	// BusyType is the busy time type of the time outside the opening hours, one of the BusyType
+	// constants. Defaults to BusyTypeUnavailable when empty.
+	BusyType string
+
+	// Priority orders overlapping components, from 1 for the highest priority to 9 for the lowest.
+	// Components without priority (0) come last.
+	Priority int
+}
+
+// rank returns the rank of the priority of the availability, lower ranks coming first.
+func (av Availability) rank() int {
+	if av.Priority == 0 {
+		return 10
+	}
+
+	return av.Priority
+}
+
+// MarshalAvailability returns the availabilities as an iCalendar with one VAVAILABILITY component
+// each. Ranges starting at the same time of the day and lasting as long are grouped into a single
+// AVAILABLE sub-component recurring on several weekdays.
+//
+// The availabilities are given in the time zone of their schedule, or in opts.Location when it is
+// unknown. Their AVAILABLE sub-components start in the week containing their start, or opts.Start
+// when they have none. The time zones are described like in MarshalICalendar.
+func MarshalAvailability(avs []Availability, opts ICalendarOptions) ([]byte, error) {
+	opts = opts.withDefaults()
+
+	locations := make([]*time.Location, len(avs))
+	for i, av := range avs {
+		locations[i] = av.Schedule.Location
+		if locations[i] == nil {
+			locations[i] = opts.Location
+		}
+		if locations[i] == nil {
+			return nil, fmt.Errorf("missing time zone")
+		}
+
+		if av.Priority < 0 || av.Priority > 9 {
+			return nil, fmt.Errorf("invalid priority `%d`: expected to be between 0 and 9", av.Priority)
+		}
+		for _, oh := range av.Schedule.Hours {
+			if err := oh.Validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var w icalWriter
+	w.begin(opts)
+
+	var written []string
+	for _, loc := range locations {
+		if !slices.Contains(written, loc.String()) {
+			w.timezone(loc, startOfWeek(opts.Start, loc).Year())
+			written = append(written, loc.String())
+		}
+	}
+
+	for i, av := range avs {
+		avOpts := opts
+		avOpts.Location = locations[i]
+
+		start := opts.Start
+		if !av.Start.IsZero() {
+			start = av.Start
+		}
+		monday := startOfWeek(start, avOpts.Location)
+
+		uid := fmt.Sprintf("availability-%d", i+1)
+
+		w.line("BEGIN", "VAVAILABILITY")
+		w.line("UID", uid+"@"+opts.UIDDomain)
+		w.line("DTSTAMP", opts.Timestamp.UTC().Format(icalDateTimeFormat)+"Z")
+		if !av.Start.IsZero() {
+			w.dateTime("DTSTART", wallClock(av.Start, avOpts.Location), avOpts.Location)
+		}
+		if !av.End.IsZero() {
+			w.dateTime("DTEND", wallClock(av.End, avOpts.Location), avOpts.Location)
+		}
+		if av.BusyType != "" {
+			w.line("BUSYTYPE", av.BusyType)
+		}
+		if av.Priority != 0 {
+			w.line("PRIORITY", strconv.Itoa(av.Priority))
+		}
+
+		for _, group := range availableGroups(av.Schedule.Hours) {
+			start := monday.Add(group.start)
+
+			w.line("BEGIN", "AVAILABLE")
+			// The UID of the availability is prepended to keep the UIDs unique when several
+			// availabilities share a range.
+			w.line("UID", uid+"-"+icalUID(group.first, opts))
+			w.line("DTSTAMP", opts.Timestamp.UTC().Format(icalDateTimeFormat)+"Z")
+			w.occurrences(start, start.Add(group.duration), group.weekdays, avOpts)
+			w.line("SUMMARY", icalEscape(opts.Summary))
+			w.line("END", "AVAILABLE")
+		}
+
+		w.line("END", "VAVAILABILITY")
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.Bytes(), nil
+}
+
+// availableGroup is a set of ranges starting at the same time of the day on several weekdays and
+// lasting as long.
+type availableGroup struct {
+	first    OpeningHours
+	start    time.Duration
+	duration time.Duration
+	weekdays []time.Weekday
+}
+
+// availableGroups returns the ranges of the opening hours grouped by time of the day and duration,
+// in the order of the first range of each group.
+func availableGroups(ohs []OpeningHours) []*availableGroup {
+	var groups []*availableGroup
+	for _, oh := range openingHoursFromIntervals(weekIntervals(ohs)) {
+		wi, _ := oh.interval()
+		weekday := time.Weekday((wi.start/day + 1) % 7)
+
+		i := slices.IndexFunc(groups, func(g *availableGroup) bool {
+			return g.start%day == wi.start%day && g.duration == wi.end-wi.start
+		})
+		if i >= 0 {
+			groups[i].weekdays = append(groups[i].weekdays, weekday)
+			continue
+		}
+
+		groups = append(groups, &availableGroup{
+			first:    oh,
+			start:    wi.start,
+			duration: wi.end - wi.start,
+			weekdays: []time.Weekday{weekday},
+		})
+	}
+
+	return groups
+}
+
+// ParseAvailability parses the VAVAILABILITY components of an iCalendar. The components are
+// returned by priority, highest first, keeping their order in the calendar for equal priorities.
+//
+// AVAILABLE sub-components must recur weekly without end, as other recurrences can't be
+// expressed as opening hours. Their exceptions are ignored. Floating times, which aren't bound to
+// any time zone, result in a schedule with an unknown time zone.
+func ParseAvailability(data []byte) ([]Availability, error) {
+	calendars, err := parseICalendar(data)
+	if err != nil {
+		return nil, err
+	}
+
+	avs := []Availability{}
+	for _, calendar := range calendars {
+		for _, c := range calendar.subcomponents("VAVAILABILITY") {
+			av, err := parseAvailability(c)
+			if err != nil {
+				uid, _ := c.property("UID")
+				return nil, fmt.Errorf("invalid availability `%s`: %s", uid.value, err)
+			}
+			avs = append(avs, av)
+		}
+	}
+
+	slices.SortStableFunc(avs, func(a, b Availability) int {
+		return a.rank() - b.rank()
+	})
+
+	return avs, nil
+}
+
+func parseAvailability(c *icalComponent) (Availability, error) {
+	av := Availability{BusyType: BusyTypeUnavailable}
+	if p, ok := c.property("BUSYTYPE"); ok {
+		av.BusyType = strings.ToUpper(p.value)
+	}
+	if p, ok := c.property("PRIORITY"); ok {
+		priority, err := strconv.Atoi(p.value)
+		if err != nil || priority < 0 || priority > 9 {
+			return Availability{}, fmt.Errorf("invalid priority `%s`: expected to be between 0 and 9", p.value)
+		}
+		av.Priority = priority
+	}
+
+	var intervals []weekInterval
+	for i, available := range c.subcomponents("AVAILABLE") {
+		wis, loc, err := available.weeklyIntervals(time.Time{})
+		if err != nil {
+			return Availability{}, err
+		}
+		if i > 0 && locationName(loc) != locationName(av.Schedule.Location) {
+			return Availability{}, fmt.Errorf("available times in different time zones")
+		}
+		av.Schedule.Location = loc
+		intervals = append(intervals, wis...)
+	}
+	av.Schedule.Hours = openingHoursFromIntervals(intervals)
+
+	if _, ok := c.property("DTSTART"); ok {
+		start, loc, d, err := c.span()
+		if err != nil {
+			return Availability{}, err
+		}
+		if loc == nil {
+			loc = av.Schedule.location()
+		}
+
+		av.Start = inLocation(start, loc)
+
+		_, hasEnd := c.property("DTEND")
+		_, hasDuration := c.property("DURATION")
+		if hasEnd || hasDuration {
+			av.End = inLocation(start.Add(d), loc)
+		}
+	}
+
+	return av, nil
+}
+
+// ResolveAvailability returns the opening hours during which the availabilities make someone
+// available in the week containing t, in the given location. Where availabilities overlap, the
+// one with the highest priority applies, see ParseAvailability. Time not covered by any
+// availability is considered unavailable.
+//
+// As the periods of the availabilities start and end at given dates, the result is only valid
+// for the week containing t.
+func ResolveAvailability(avs []Availability, t time.Time, loc *time.Location) []OpeningHours {
+	avs = slices.Clone(avs)
+	slices.SortStableFunc(avs, func(a, b Availability) int {
+		return a.rank() - b.rank()
+	})
+
+	monday := startOfWeek(t, loc)
+	window := Interval{Start: inLocation(monday, loc), End: inLocation(monday.Add(week), loc)}
+
+	undecided := []Interval{window}
+	var intervals []weekInterval
+	for _, av := range avs {
+		period := window
+		if !av.Start.IsZero() && av.Start.After(period.Start) {
+			period.Start = av.Start
+		}
+		if !av.End.IsZero() && av.End.Before(period.End) {
+			period.End = av.End
+		}
+		if !period.Start.Before(period.End) {
+			continue
+		}
+
+		for _, u := range undecided {
+			covered, ok := u.intersection(period)
+			if !ok {
+				continue
+			}
+
+			for o := range av.Schedule.Occurrences(covered.Start, covered.End) {
+				intervals = append(intervals, weekInterval{start: wallOffset(o.Start, loc, monday), end: wallOffset(o.End, loc, monday)})
+			}
+		}
+
+		undecided = subtractInterval(undecided, period)
+	}
+
+	return openingHoursFromIntervals(intervals)
+}
+
+// intersection returns the time both intervals have in common. The second return value is false
+// when they have none.
+func (i Interval) intersection(other Interval) (Interval, bool) {
+	if !i.overlaps(other) {
+		return Interval{}, false
+	}
+	if other.Start.After(i.Start) {
+		i.Start = other.Start
+	}
+	if other.End.Before(i.End) {
+		i.End = other.End
+	}
+
+	return i, true
+}
+
+// subtractInterval returns the parts of the intervals not covered by the given interval.
+func subtractInterval(intervals []Interval, other Interval) []Interval {
+	var remaining []Interval
+	for _, i := range intervals {
+		if !i.overlaps(other) {
+			remaining = append(remaining, i)
+			continue
+		}
+		if i.Start.Before(other.Start) {
+			remaining = append(remaining, Interval{Start: i.Start, End: other.Start})
+		}
+		if other.End.Before(i.End) {
+			remaining = append(remaining, Interval{Start: other.End, End: i.End})
+		}
+	}
+
+	return remaining
+}
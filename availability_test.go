@@ -0,0 +1,491 @@
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalAvailability(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timestamp := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		availabilities []Availability
+		opts           ICalendarOptions
+		expectedResult []string
+		expectedError  error
+	}{
+		"when several availabilities": {
+			availabilities: []Availability{
+				{
+					Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00,W6T10:00:00/W6T14:00:00"), Location: amsterdam},
+				},
+				{
+					Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T10:00:00/W1T14:00:00")},
+					Start:    time.Date(2026, 12, 21, 0, 0, 0, 0, amsterdam),
+					End:      time.Date(2027, 1, 4, 0, 0, 0, 0, amsterdam),
+					BusyType: BusyTypeBusy,
+					Priority: 1,
+				},
+			},
+			opts: ICalendarOptions{
+				Location:  amsterdam,
+				Start:     start,
+				Timestamp: timestamp,
+				Closures:  []time.Time{time.Date(2026, 12, 29, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VTIMEZONE",
+				"TZID:Europe/Amsterdam",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:20250330T020000",
+				"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+				"TZOFFSETFROM:+0100",
+				"TZOFFSETTO:+0200",
+				"TZNAME:CEST",
+				"END:DAYLIGHT",
+				"BEGIN:STANDARD",
+				"DTSTART:20251026T030000",
+				"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+				"TZOFFSETFROM:+0200",
+				"TZOFFSETTO:+0100",
+				"TZNAME:CET",
+				"END:STANDARD",
+				"END:VTIMEZONE",
+				"BEGIN:VAVAILABILITY",
+				"UID:availability-1@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"BEGIN:AVAILABLE",
+				"UID:availability-1-W1T080000-W1T160000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261019T080000",
+				"DTEND;TZID=Europe/Amsterdam:20261019T160000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO,TU",
+				"EXDATE;TZID=Europe/Amsterdam:20261229T080000",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"BEGIN:AVAILABLE",
+				"UID:availability-1-W6T100000-W6T140000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261024T100000",
+				"DTEND;TZID=Europe/Amsterdam:20261024T140000",
+				"RRULE:FREQ=WEEKLY;BYDAY=SA",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"BEGIN:VAVAILABILITY",
+				"UID:availability-2@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261221T000000",
+				"DTEND;TZID=Europe/Amsterdam:20270104T000000",
+				"BUSYTYPE:BUSY",
+				"PRIORITY:1",
+				"BEGIN:AVAILABLE",
+				"UID:availability-2-W1T100000-W1T140000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261221T100000",
+				"DTEND;TZID=Europe/Amsterdam:20261221T140000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"END:VCALENDAR",
+			},
+		},
+		"when in UTC": {
+			availabilities: []Availability{{
+				Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W7T22:00:00/W1T06:00:00"), Location: time.UTC},
+			}},
+			opts: ICalendarOptions{Start: start, UIDDomain: "example.com", Timestamp: timestamp},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VAVAILABILITY",
+				"UID:availability-1@example.com",
+				"DTSTAMP:20261018T120000Z",
+				"BEGIN:AVAILABLE",
+				"UID:availability-1-W7T220000-W1T060000@example.com",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART:20261025T220000Z",
+				"DTEND:20261026T060000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=SU",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"END:VCALENDAR",
+			},
+		},
+		"when availabilities share a range": {
+			availabilities: []Availability{
+				{Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00"), Location: time.UTC}},
+				{Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00"), Location: time.UTC}, Priority: 1},
+			},
+			opts: ICalendarOptions{Start: start, Timestamp: timestamp},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VAVAILABILITY",
+				"UID:availability-1@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"BEGIN:AVAILABLE",
+				"UID:availability-1-W1T080000-W1T180000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T180000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"BEGIN:VAVAILABILITY",
+				"UID:availability-2@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"PRIORITY:1",
+				"BEGIN:AVAILABLE",
+				"UID:availability-2-W1T080000-W1T180000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T180000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO",
+				"SUMMARY:Open",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"END:VCALENDAR",
+			},
+		},
+		"when time zone missing": {
+			availabilities: []Availability{{Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T08:00:00/W1T16:00:00")}}},
+			opts:           ICalendarOptions{Start: start},
+			expectedError:  fmt.Errorf("missing time zone"),
+		},
+		"when priority invalid": {
+			availabilities: []Availability{{Schedule: ZonedSchedule{Location: time.UTC}, Priority: 10}},
+			opts:           ICalendarOptions{Start: start},
+			expectedError:  fmt.Errorf("invalid priority `10`: expected to be between 0 and 9"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := MarshalAvailability(tt.availabilities, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedResult == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, strings.Join(tt.expectedResult, "\r\n")+"\r\n", string(result))
+			}
+		})
+	}
+}
+
+func TestParseAvailability(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+
+	calendar := func(lines ...string) []byte {
+		lines = append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Example//EN"}, lines...), "END:VCALENDAR")
+		return []byte(strings.Join(lines, "\r\n") + "\r\n")
+	}
+	available := func(lines ...string) []string {
+		return append(append([]string{"BEGIN:VAVAILABILITY", "UID:a1", "DTSTAMP:20261018T120000Z", "BEGIN:AVAILABLE", "UID:a1-1", "DTSTAMP:20261018T120000Z"}, lines...), "END:AVAILABLE", "END:VAVAILABILITY")
+	}
+
+	type expectedAvailability struct {
+		schedule string
+		start    time.Time
+		end      time.Time
+		busyType string
+		priority int
+	}
+
+	tests := map[string]struct {
+		data           []byte
+		expectedResult []expectedAvailability
+		expectedError  error
+	}{
+		"when several availabilities": {
+			data: calendar(
+				"BEGIN:VAVAILABILITY",
+				"UID:base",
+				"DTSTAMP:20261018T120000Z",
+				"BEGIN:AVAILABLE",
+				"UID:weekdays",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261019T080000",
+				"DTEND;TZID=Europe/Amsterdam:20261019T160000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+				"EXDATE;TZID=Europe/Amsterdam:20261225T080000",
+				"END:AVAILABLE",
+				"BEGIN:AVAILABLE",
+				"UID:saturday",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261024T100000",
+				"DURATION:PT4H",
+				"RRULE:FREQ=WEEKLY",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+				"BEGIN:VAVAILABILITY",
+				"UID:holidays",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261221T000000",
+				"DURATION:P2W",
+				"BUSYTYPE:busy-tentative",
+				"PRIORITY:1",
+				"BEGIN:AVAILABLE",
+				"UID:holidays-mornings",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261221T090000",
+				"DTEND;TZID=Europe/Amsterdam:20261221T12",
+				" 0000",
+				"RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE;WKST=MO",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+			),
+			expectedResult: []expectedAvailability{
+				{
+					schedule: "W1T09:00:00/W1T12:00:00,W3T09:00:00/W3T12:00:00;tz=Europe/Amsterdam",
+					start:    time.Date(2026, 12, 21, 0, 0, 0, 0, amsterdam),
+					end:      time.Date(2027, 1, 4, 0, 0, 0, 0, amsterdam),
+					busyType: BusyTypeTentative,
+					priority: 1,
+				},
+				{
+					schedule: "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00,W3T08:00:00/W3T16:00:00,W4T08:00:00/W4T16:00:00,W5T08:00:00/W5T16:00:00,W6T10:00:00/W6T14:00:00;tz=Europe/Amsterdam",
+					busyType: BusyTypeUnavailable,
+				},
+			},
+		},
+		"when overnight in UTC": {
+			data: calendar(available(
+				"DTSTART:20261025T220000Z",
+				"DTEND:20261026T060000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=SU",
+			)...),
+			expectedResult: []expectedAvailability{{schedule: "W7T22:00:00/W1T06:00:00;tz=UTC", busyType: BusyTypeUnavailable}},
+		},
+		"when lasting a whole week": {
+			data: calendar(available(
+				"DTSTART;VALUE=DATE:20261019",
+				"DURATION:P7D",
+				"RRULE:FREQ=WEEKLY",
+			)...),
+			expectedResult: []expectedAvailability{{schedule: TwentyFourSevenString, busyType: BusyTypeUnavailable}},
+		},
+		"when no availability": {
+			data:           calendar(),
+			expectedResult: []expectedAvailability{},
+		},
+		"when not recurring": {
+			data: calendar(available(
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T160000Z",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: missing RRULE: expected to recur weekly"),
+		},
+		"when recurring monthly": {
+			data: calendar(available(
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T160000Z",
+				"RRULE:FREQ=MONTHLY;BYDAY=1MO",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: unsupported RRULE `FREQ=MONTHLY;BYDAY=1MO`: expected to recur weekly"),
+		},
+		"when recurring every other week": {
+			data: calendar(available(
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T160000Z",
+				"RRULE:FREQ=WEEKLY;INTERVAL=2",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: unsupported RRULE `FREQ=WEEKLY;INTERVAL=2`: expected to recur every week"),
+		},
+		"when recurrence ends": {
+			data: calendar(available(
+				"DTSTART:20261019T080000Z",
+				"DTEND:20261019T160000Z",
+				"RRULE:FREQ=WEEKLY;COUNT=10",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: unsupported RRULE `FREQ=WEEKLY;COUNT=10`: expected to recur without end"),
+		},
+		"when lasting longer than a week": {
+			data: calendar(available(
+				"DTSTART:20261019T080000Z",
+				"DURATION:P8D",
+				"RRULE:FREQ=WEEKLY",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: invalid duration `192h0m0s`: expected to be positive and at most a week"),
+		},
+		"when time zone unknown": {
+			data: calendar(available(
+				"DTSTART;TZID=Europe/Atlantis:20261019T080000",
+				"DTEND;TZID=Europe/Atlantis:20261019T160000",
+				"RRULE:FREQ=WEEKLY",
+			)...),
+			expectedError: fmt.Errorf("invalid availability `a1`: invalid time zone `Europe/Atlantis`"),
+		},
+		"when in different time zones": {
+			data: calendar(
+				"BEGIN:VAVAILABILITY",
+				"UID:a1",
+				"BEGIN:AVAILABLE",
+				"DTSTART;TZID=Europe/Amsterdam:20261019T080000",
+				"DTEND;TZID=Europe/Amsterdam:20261019T160000",
+				"RRULE:FREQ=WEEKLY",
+				"END:AVAILABLE",
+				"BEGIN:AVAILABLE",
+				"DTSTART;TZID=Europe/London:20261020T080000",
+				"DTEND;TZID=Europe/London:20261020T160000",
+				"RRULE:FREQ=WEEKLY",
+				"END:AVAILABLE",
+				"END:VAVAILABILITY",
+			),
+			expectedError: fmt.Errorf("invalid availability `a1`: available times in different time zones"),
+		},
+		"when priority invalid": {
+			data:          calendar("BEGIN:VAVAILABILITY", "UID:a1", "PRIORITY:high", "END:VAVAILABILITY"),
+			expectedError: fmt.Errorf("invalid availability `a1`: invalid priority `high`: expected to be between 0 and 9"),
+		},
+		"when component not ended": {
+			data:          []byte("BEGIN:VCALENDAR\r\nBEGIN:VAVAILABILITY\r\nEND:VCALENDAR\r\n"),
+			expectedError: fmt.Errorf("invalid iCalendar: unexpected `END:VCALENDAR`"),
+		},
+		"when content line invalid": {
+			data:          []byte("BEGIN:VCALENDAR\r\nVERSION\r\nEND:VCALENDAR\r\n"),
+			expectedError: fmt.Errorf("invalid iCalendar: invalid content line `VERSION`"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseAvailability(tt.data)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedResult == nil {
+				assert.Nil(t, result)
+				return
+			}
+
+			actual := make([]expectedAvailability, 0, len(result))
+			for _, av := range result {
+				actual = append(actual, expectedAvailability{
+					schedule: av.Schedule.String(),
+					start:    av.Start,
+					end:      av.End,
+					busyType: av.BusyType,
+					priority: av.Priority,
+				})
+			}
+			assert.Equal(t, len(tt.expectedResult), len(actual))
+			for i := range actual {
+				assert.Equal(t, tt.expectedResult[i].schedule, actual[i].schedule)
+				assert.True(t, tt.expectedResult[i].start.Equal(actual[i].start))
+				assert.True(t, tt.expectedResult[i].end.Equal(actual[i].end))
+				assert.Equal(t, tt.expectedResult[i].busyType, actual[i].busyType)
+				assert.Equal(t, tt.expectedResult[i].priority, actual[i].priority)
+			}
+		})
+	}
+}
+
+func TestResolveAvailability(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	availabilities := []Availability{
+		{
+			Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T08:00:00/W1T16:00:00,W3T08:00:00/W3T16:00:00,W7T22:00:00/W1T02:00:00"), Location: amsterdam},
+		},
+		{
+			Schedule: ZonedSchedule{Hours: mustParseOpeningHours("W1T10:00:00/W1T14:00:00,W3T10:00:00/W3T14:00:00"), Location: amsterdam},
+			Start:    time.Date(2026, 12, 23, 0, 0, 0, 0, amsterdam),
+			End:      time.Date(2027, 1, 4, 0, 0, 0, 0, amsterdam),
+			Priority: 1,
+		},
+	}
+
+	tests := map[string]struct {
+		availabilities []Availability
+		t              time.Time
+		loc            *time.Location
+		expectedResult string
+	}{
+		"when only the availability without priority applies": {
+			availabilities: availabilities,
+			t:              time.Date(2026, 12, 16, 12, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: "W1T08:00:00/W1T16:00:00,W3T08:00:00/W3T16:00:00,W7T22:00:00/W1T02:00:00",
+		},
+		"when the availability with priority applies from the middle of the week": {
+			availabilities: availabilities,
+			t:              time.Date(2026, 12, 23, 12, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: "W1T00:00:00/W1T02:00:00,W1T08:00:00/W1T16:00:00,W3T10:00:00/W3T14:00:00",
+		},
+		"when the availability with priority applies the whole week": {
+			availabilities: availabilities,
+			t:              time.Date(2026, 12, 30, 12, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: "W1T10:00:00/W1T14:00:00,W3T10:00:00/W3T14:00:00",
+		},
+		"when in another time zone": {
+			availabilities: availabilities,
+			t:              time.Date(2026, 12, 30, 12, 0, 0, 0, time.UTC),
+			loc:            time.UTC,
+			expectedResult: "W1T09:00:00/W1T13:00:00,W3T09:00:00/W3T13:00:00,W7T23:00:00/W7T24:00:00",
+		},
+		"when no availability applies": {
+			t:              time.Date(2026, 12, 30, 12, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: "",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := ResolveAvailability(tt.availabilities, tt.t, tt.loc)
+			assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+		})
+	}
+}
+
+func TestAvailabilityRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	availabilities := []Availability{{
+		Schedule: ZonedSchedule{
+			Hours:    mustParseOpeningHours("W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00,W5T22:00:00/W6T04:00:00,W7T09:30:00/W7T12:00:00"),
+			Location: mustLoadLocation("America/New_York"),
+		},
+		BusyType: BusyTypeUnavailable,
+		Priority: 3,
+	}}
+
+	data, err := MarshalAvailability(availabilities, ICalendarOptions{Start: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+
+	result, err := ParseAvailability(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, availabilities[0].Schedule.String(), result[0].Schedule.String())
+	assert.Equal(t, availabilities[0].BusyType, result[0].BusyType)
+	assert.Equal(t, availabilities[0].Priority, result[0].Priority)
+}
@@ -0,0 +1,133 @@
+package openinghours
+
+import (
+	"math/bits"
+	"time"
+)
+
+// minutesPerWeek is the number of minutes in a week, and the number of bits of a WeekBitmap.
+const minutesPerWeek = 7 * 1440
+
+// WeekBitmap is a compact representation of opening hours, with one bit per minute of the week
+// telling whether it is open. Bit 0 is monday 00:00-00:01 and bit 10079 is sunday 23:59-24:00.
+//
+// Its size is fixed, so that it can be stored in arrays, and answering whether it is open at a
+// given time takes constant time. Opening hours with seconds are rounded to the minute like
+// GetOCPIOpeningTimes: opening times are rounded down and closing times are rounded up.
+type WeekBitmap [(minutesPerWeek + 63) / 64]uint64
+
+// NewWeekBitmap returns the bitmap of the opening hours. An error is returned when any of the
+// opening hours is invalid.
+func NewWeekBitmap(ohs []OpeningHours) (WeekBitmap, error) {
+	var b WeekBitmap
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return WeekBitmap{}, err
+		}
+	}
+
+	for _, wi := range weekIntervals(ohs) {
+		start := int(wi.start / time.Minute)
+		end := int((wi.end + time.Minute - 1) / time.Minute)
+		b.setRange(start, end)
+	}
+
+	return b, nil
+}
+
+// setRange sets the bits of the minutes in [start, end).
+func (b *WeekBitmap) setRange(start, end int) {
+	for m := start; m < end; {
+		word, bit := m/64, m%64
+		n := min(64-bit, end-m)
+		mask := ^uint64(0) >> (64 - n) << bit
+		b[word] |= mask
+		m += n
+	}
+}
+
+// IsOpen reports whether the opening hours are open at the given time in the week, ignoring its
+// seconds. Times outside the week, including 24:00 on sunday, are never open.
+func (b *WeekBitmap) IsOpen(tiw TimeInWeek) bool {
+	m := (tiw.Weekday-1)*1440 + tiw.MinutesSinceMidnight
+	if tiw.Weekday < 1 || m < 0 || m >= minutesPerWeek {
+		return false
+	}
+
+	return b[m/64]&(1<<(m%64)) != 0
+}
+
+// IsOpenAt reports whether the opening hours are open at instant t, following the wall clock of
+// the given location. Contrary to Occurrences, wall clock times occurring twice when the clocks go
+// back are open both times.
+func (b *WeekBitmap) IsOpenAt(t time.Time, loc *time.Location) bool {
+	t = t.In(loc)
+	weekday := (int(t.Weekday())+6)%7 + 1
+
+	return b.IsOpen(TimeInWeek{Weekday: weekday, MinutesSinceMidnight: t.Hour()*60 + t.Minute()})
+}
+
+// Union returns the bitmap open whenever either bitmap is open.
+func (b *WeekBitmap) Union(other *WeekBitmap) WeekBitmap {
+	var result WeekBitmap
+	for i := range b {
+		result[i] = b[i] | other[i]
+	}
+
+	return result
+}
+
+// Intersection returns the bitmap open whenever both bitmaps are open.
+func (b *WeekBitmap) Intersection(other *WeekBitmap) WeekBitmap {
+	var result WeekBitmap
+	for i := range b {
+		result[i] = b[i] & other[i]
+	}
+
+	return result
+}
+
+// OpenMinutes returns the number of minutes per week during which the bitmap is open.
+func (b *WeekBitmap) OpenMinutes() int {
+	n := 0
+	for _, word := range b {
+		n += bits.OnesCount64(word)
+	}
+
+	return n
+}
+
+// OpenMinutesPerDay returns the number of minutes during which the bitmap is open on each day of
+// the week, monday first.
+func (b *WeekBitmap) OpenMinutesPerDay() [7]int {
+	var days [7]int
+	for weekday := range days {
+		for m := weekday * 1440; m < (weekday+1)*1440; {
+			word, bit := m/64, m%64
+			n := min(64-bit, (weekday+1)*1440-m)
+			days[weekday] += bits.OnesCount64(b[word] & (^uint64(0) >> (64 - n) << bit))
+			m += n
+		}
+	}
+
+	return days
+}
+
+// OpeningHours does the opposite of NewWeekBitmap, returning the opening hours of the bitmap.
+func (b *WeekBitmap) OpeningHours() []OpeningHours {
+	var intervals []weekInterval
+	for m := 0; m < minutesPerWeek; {
+		if b[m/64]&(1<<(m%64)) == 0 {
+			m++
+			continue
+		}
+
+		start := m
+		for m < minutesPerWeek && b[m/64]&(1<<(m%64)) != 0 {
+			m++
+		}
+		intervals = append(intervals, weekInterval{start: time.Duration(start) * time.Minute, end: time.Duration(m) * time.Minute})
+	}
+
+	return openingHoursFromIntervals(intervals)
+}
@@ -0,0 +1,202 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWeekBitmap(t *testing.T) {
+	tests := map[string]struct {
+		openingHours        []OpeningHours
+		expectedResult      string
+		expectedOpenMinutes int
+		expectedPerDay      [7]int
+		expectedError       error
+	}{
+		"when regular": {
+			openingHours:        mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W3T08:00:00/W3T12:30:00"),
+			expectedResult:      "W1T08:00:00/W1T18:00:00,W3T08:00:00/W3T12:30:00",
+			expectedOpenMinutes: 870,
+			expectedPerDay:      [7]int{600, 0, 270, 0, 0, 0, 0},
+		},
+		"when overnight around the end of the week": {
+			openingHours:        mustParseOpeningHours("W7T22:00:00/W1T06:00:00"),
+			expectedResult:      "W7T22:00:00/W1T06:00:00",
+			expectedOpenMinutes: 480,
+			expectedPerDay:      [7]int{360, 0, 0, 0, 0, 0, 120},
+		},
+		"when seconds": {
+			openingHours:        mustParseOpeningHours("W2T08:00:30/W2T08:10:15"),
+			expectedResult:      "W2T08:00:00/W2T08:11:00",
+			expectedOpenMinutes: 11,
+			expectedPerDay:      [7]int{0, 11, 0, 0, 0, 0, 0},
+		},
+		"when 24/7": {
+			openingHours:        []OpeningHours{TwentyFourSevenOH},
+			expectedResult:      TwentyFourSevenString,
+			expectedOpenMinutes: 10080,
+			expectedPerDay:      [7]int{1440, 1440, 1440, 1440, 1440, 1440, 1440},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: "",
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := NewWeekBitmap(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(b.OpeningHours()))
+			assert.Equal(t, tt.expectedOpenMinutes, b.OpenMinutes())
+			assert.Equal(t, tt.expectedPerDay, b.OpenMinutesPerDay())
+		})
+	}
+}
+
+func TestWeekBitmapIsOpen(t *testing.T) {
+	b, err := NewWeekBitmap(mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W7T22:00:00/W1T02:00:00"))
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		tiw            TimeInWeek
+		expectedResult bool
+	}{
+		"when at opening":           {tiw: TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480}, expectedResult: true},
+		"when before opening":       {tiw: TimeInWeek{Weekday: 1, MinutesSinceMidnight: 479, Seconds: 59}, expectedResult: false},
+		"when at closing":           {tiw: TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1080}, expectedResult: false},
+		"when at start of the week": {tiw: TimeInWeek{Weekday: 1, MinutesSinceMidnight: 0}, expectedResult: true},
+		"when at end of the week":   {tiw: TimeInWeek{Weekday: 7, MinutesSinceMidnight: 1439}, expectedResult: true},
+		"when after the week":       {tiw: TimeInWeek{Weekday: 7, MinutesSinceMidnight: 1440}, expectedResult: false},
+		"when weekday invalid":      {tiw: TimeInWeek{Weekday: 0, MinutesSinceMidnight: 1}, expectedResult: false},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expectedResult, b.IsOpen(tt.tiw))
+		})
+	}
+}
+
+func TestWeekBitmapIsOpenAt(t *testing.T) {
+	t.Parallel()
+
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	b, err := NewWeekBitmap(mustParseOpeningHours("W1T08:00:00/W1T18:00:00"))
+	assert.NoError(t, err)
+
+	assert.True(t, b.IsOpenAt(time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC), amsterdam))
+	assert.False(t, b.IsOpenAt(time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC), amsterdam))
+	assert.True(t, b.IsOpenAt(time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC), amsterdam))
+}
+
+func TestWeekBitmapUnionAndIntersection(t *testing.T) {
+	t.Parallel()
+
+	a, err := NewWeekBitmap(mustParseOpeningHours("W1T08:00:00/W1T12:00:00,W7T20:00:00/W1T02:00:00"))
+	assert.NoError(t, err)
+	b, err := NewWeekBitmap(mustParseOpeningHours("W1T10:00:00/W1T14:00:00,W7T23:00:00/W7T24:00:00"))
+	assert.NoError(t, err)
+
+	union := a.Union(&b)
+	assert.Equal(t, "W1T08:00:00/W1T14:00:00,W7T20:00:00/W1T02:00:00", OpeningHoursSliceToString(union.OpeningHours()))
+
+	intersection := a.Intersection(&b)
+	assert.Equal(t, "W1T10:00:00/W1T12:00:00,W7T23:00:00/W7T24:00:00", OpeningHoursSliceToString(intersection.OpeningHours()))
+	assert.Equal(t, 180, intersection.OpenMinutes())
+}
+
+// benchmarkOpeningHours are the opening hours of a station open on weekdays with a lunch break,
+// on saturday mornings and on friday night.
+var benchmarkOpeningHours = mustParseOpeningHours("W1T08:00:00/W1T12:00:00,W1T13:00:00/W1T18:00:00,W2T08:00:00/W2T12:00:00,W2T13:00:00/W2T18:00:00,W3T08:00:00/W3T12:00:00,W3T13:00:00/W3T18:00:00,W4T08:00:00/W4T12:00:00,W4T13:00:00/W4T18:00:00,W5T08:00:00/W5T12:00:00,W5T13:00:00/W5T18:00:00,W5T22:00:00/W6T02:00:00,W6T09:00:00/W6T12:00:00")
+
+// benchmarkTimes are times spread over the week, so that both open and closed times are looked up.
+var benchmarkTimes = func() []TimeInWeek {
+	var times []TimeInWeek
+	for m := 0; m < minutesPerWeek; m += 97 {
+		times = append(times, TimeInWeek{Weekday: m/1440 + 1, MinutesSinceMidnight: m % 1440})
+	}
+	return times
+}()
+
+func BenchmarkWeekBitmapIsOpen(b *testing.B) {
+	bitmap, err := NewWeekBitmap(benchmarkOpeningHours)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitmap.IsOpen(benchmarkTimes[i%len(benchmarkTimes)])
+	}
+}
+
+func BenchmarkOpeningHoursIsOpen(b *testing.B) {
+	isOpen := func(ohs []OpeningHours, tiw TimeInWeek) bool {
+		offset := tiw.offset()
+		for _, oh := range ohs {
+			wi, ok := oh.interval()
+			if !ok {
+				continue
+			}
+			if (offset >= wi.start && offset < wi.end) || (offset+week >= wi.start && offset+week < wi.end) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < b.N; i++ {
+		isOpen(benchmarkOpeningHours, benchmarkTimes[i%len(benchmarkTimes)])
+	}
+}
+
+func BenchmarkWeekBitmapIntersection(b *testing.B) {
+	bitmap, err := NewWeekBitmap(benchmarkOpeningHours)
+	if err != nil {
+		b.Fatal(err)
+	}
+	offPeak, err := NewWeekBitmap(mustParseOpeningHours("W1T22:00:00/W2T07:00:00,W2T22:00:00/W3T07:00:00,W3T22:00:00/W4T07:00:00,W4T22:00:00/W5T07:00:00,W5T22:00:00/W1T07:00:00"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		intersection := bitmap.Intersection(&offPeak)
+		intersection.OpenMinutes()
+	}
+}
+
+func BenchmarkOpeningHoursIntersection(b *testing.B) {
+	offPeak := mustParseOpeningHours("W1T22:00:00/W2T07:00:00,W2T22:00:00/W3T07:00:00,W3T22:00:00/W4T07:00:00,W4T22:00:00/W5T07:00:00,W5T22:00:00/W1T07:00:00")
+
+	for i := 0; i < b.N; i++ {
+		var minutes time.Duration
+		for _, a := range weekIntervals(benchmarkOpeningHours) {
+			for _, o := range weekIntervals(offPeak) {
+				if start, end := max(a.start, o.start), min(a.end, o.end); start < end {
+					minutes += end - start
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package openinghours
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNeverOpen is returned when an operation requires the opening hours to be open at some point,
+// but they never are.
+var ErrNeverOpen = errors.New("opening hours are never open")
+
+// AddOpenDuration returns the instant at which the given duration has elapsed counting only the
+// time during which the opening hours are open, starting at start. For example, with opening hours
+// from 09:00 to 17:00 on weekdays, adding 4 hours to friday 15:00 results in monday 11:00.
+//
+// When the duration runs out exactly at closing time, the closing time is returned rather than
+// the next opening time. An error is returned when the time zone is missing, the duration is
+// negative or the opening hours are never open.
+func AddOpenDuration(ohs []OpeningHours, start time.Time, d time.Duration, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		return time.Time{}, fmt.Errorf("missing time zone")
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("invalid duration `%s`: expected to be non-negative", d)
+	}
+	if d == 0 {
+		return start, nil
+	}
+	if len(weekIntervals(ohs)) == 0 {
+		return time.Time{}, ErrNeverOpen
+	}
+
+	for from := start; ; from = from.Add(week) {
+		for period := range Occurrences(ohs, from, from.Add(week), loc) {
+			if period.Duration() >= d {
+				return period.Start.Add(d), nil
+			}
+			d -= period.Duration()
+		}
+	}
+}
+
+// OpenDurationBetween returns the time during which the opening hours are open between a and b.
+// The result is negative when b is before a. An error is returned when the time zone is missing.
+func OpenDurationBetween(ohs []OpeningHours, a, b time.Time, loc *time.Location) (time.Duration, error) {
+	if loc == nil {
+		return 0, fmt.Errorf("missing time zone")
+	}
+	if b.Before(a) {
+		d, err := OpenDurationBetween(ohs, b, a, loc)
+		return -d, err
+	}
+
+	var d time.Duration
+	for period := range Occurrences(ohs, a, b, loc) {
+		d += period.Duration()
+	}
+
+	return d, nil
+}
@@ -0,0 +1,186 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const weekdaysNineToFive = "W1T09:00:00/W1T17:00:00,W2T09:00:00/W2T17:00:00,W3T09:00:00/W3T17:00:00,W4T09:00:00/W4T17:00:00,W5T09:00:00/W5T17:00:00"
+
+func TestAddOpenDuration(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+
+	tests := map[string]struct {
+		openingHours   string
+		start          time.Time
+		loc            *time.Location
+		duration       time.Duration
+		expectedResult time.Time
+		expectedError  error
+	}{
+		"when within the same day": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 19, 10, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       4 * time.Hour,
+			expectedResult: time.Date(2026, 10, 19, 14, 0, 0, 0, amsterdam),
+		},
+		"when starting before opening": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 19, 6, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       time.Hour,
+			expectedResult: time.Date(2026, 10, 19, 10, 0, 0, 0, amsterdam),
+		},
+		"when over the weekend": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 23, 15, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       4 * time.Hour,
+			expectedResult: time.Date(2026, 10, 26, 11, 0, 0, 0, amsterdam),
+		},
+		"when running out at closing time": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 19, 9, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       8 * time.Hour,
+			expectedResult: time.Date(2026, 10, 19, 17, 0, 0, 0, amsterdam),
+		},
+		"when spanning several weeks": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 19, 9, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       100 * time.Hour,
+			expectedResult: time.Date(2026, 11, 4, 13, 0, 0, 0, amsterdam),
+		},
+		"when overnight range": {
+			openingHours:   "W5T22:00:00/W6T04:00:00",
+			start:          time.Date(2026, 10, 23, 23, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       7 * time.Hour,
+			expectedResult: time.Date(2026, 10, 30, 24, 0, 0, 0, amsterdam),
+		},
+		"when duration is zero": {
+			openingHours:   weekdaysNineToFive,
+			start:          time.Date(2026, 10, 24, 12, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			duration:       0,
+			expectedResult: time.Date(2026, 10, 24, 12, 0, 0, 0, amsterdam),
+		},
+		"when duration is negative": {
+			openingHours:  weekdaysNineToFive,
+			start:         time.Date(2026, 10, 19, 10, 0, 0, 0, amsterdam),
+			loc:           amsterdam,
+			duration:      -time.Hour,
+			expectedError: fmt.Errorf("invalid duration `-1h0m0s`: expected to be non-negative"),
+		},
+		"when time zone missing": {
+			openingHours:  weekdaysNineToFive,
+			start:         time.Date(2026, 10, 19, 10, 0, 0, 0, amsterdam),
+			duration:      time.Hour,
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+		"when never open": {
+			openingHours:  "",
+			start:         time.Date(2026, 10, 19, 10, 0, 0, 0, amsterdam),
+			loc:           amsterdam,
+			duration:      time.Hour,
+			expectedError: ErrNeverOpen,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, err := AddOpenDuration(ohs, tt.start, tt.duration, tt.loc)
+			assert.Equal(t, tt.expectedError, err)
+			assert.True(t, tt.expectedResult.Equal(result), "result: %s", result)
+		})
+	}
+}
+
+func TestOpenDurationBetween(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+
+	tests := map[string]struct {
+		openingHours   string
+		a              time.Time
+		b              time.Time
+		loc            *time.Location
+		expectedResult time.Duration
+		expectedError  error
+	}{
+		"when over the weekend": {
+			openingHours:   weekdaysNineToFive,
+			a:              time.Date(2026, 10, 23, 15, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 26, 11, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: 4 * time.Hour,
+		},
+		"when reversed": {
+			openingHours:   weekdaysNineToFive,
+			a:              time.Date(2026, 10, 26, 11, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 23, 15, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: -4 * time.Hour,
+		},
+		"when whole week": {
+			openingHours:   weekdaysNineToFive,
+			a:              time.Date(2026, 10, 19, 0, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 26, 0, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: 40 * time.Hour,
+		},
+		"when overnight range": {
+			openingHours:   "W5T22:00:00/W6T04:00:00",
+			a:              time.Date(2026, 10, 23, 12, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 24, 2, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: 4 * time.Hour,
+		},
+		"when clocks go back": {
+			openingHours:   TwentyFourSevenString,
+			a:              time.Date(2026, 10, 25, 0, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 26, 0, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: 25 * time.Hour,
+		},
+		"when never open": {
+			openingHours:   "",
+			a:              time.Date(2026, 10, 19, 0, 0, 0, 0, amsterdam),
+			b:              time.Date(2026, 10, 26, 0, 0, 0, 0, amsterdam),
+			loc:            amsterdam,
+			expectedResult: 0,
+		},
+		"when time zone missing": {
+			openingHours:  weekdaysNineToFive,
+			a:             time.Date(2026, 10, 19, 0, 0, 0, 0, amsterdam),
+			b:             time.Date(2026, 10, 26, 0, 0, 0, 0, amsterdam),
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, err := OpenDurationBetween(ohs, tt.a, tt.b, tt.loc)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
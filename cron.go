@@ -0,0 +1,288 @@
+package openinghours
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronWeekdays are the names of the days of the week in cron expressions, sunday being 0.
+var cronWeekdays = [...]string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// cronMacros are the predefined schedules that recur every week.
+var cronMacros = map[string]string{
+	"@weekly": "0 0 * * 0",
+	"@daily":  "0 0 * * *",
+	"@hourly": "0 * * * *",
+}
+
+// CronExpressions are the cron expressions firing when the opening hours open and close.
+type CronExpressions struct {
+	Open  []string
+	Close []string
+}
+
+// GetCronExpressions returns the cron expressions firing at each opening and closing time of the
+// opening hours, in the usual five fields format "minute hour day-of-month month day-of-week".
+// Weekdays sharing the same time are grouped into a single expression. Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00,...,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00")
+//	expressions, _ := GetCronExpressions(ohs)
+//	// expressions will be:
+//	CronExpressions{
+//	    Open:  []string{"0 8 * * 1-5", "0 10 * * 6"},
+//	    Close: []string{"0 18 * * 1-5", "0 14 * * 6"},
+//	}
+//
+// Ranges following each other without interruption, such as an overnight range and the range of
+// the next morning, only open and close once. Opening hours that are always or never open have no
+// expression. As cron has no seconds, opening times are rounded down and closing times are rounded
+// up to the minute. An error is returned when any of the opening hours is invalid.
+func GetCronExpressions(ohs []OpeningHours) (CronExpressions, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return CronExpressions{}, err
+		}
+	}
+
+	intervals := weekIntervals(ohs)
+	if len(intervals) == 0 || intervals[0] == (weekInterval{start: 0, end: week}) {
+		return CronExpressions{}, nil
+	}
+
+	// The interval at the end of the week continues into the one at its start.
+	wraps := intervals[0].start == 0 && intervals[len(intervals)-1].end == week
+
+	var opens, closes []time.Duration
+	for _, wi := range intervals {
+		if wi.start != 0 || !wraps {
+			opens = append(opens, wi.start.Truncate(time.Minute))
+		}
+		if wi.end != week || !wraps {
+			closes = append(closes, (wi.end + time.Minute - 1).Truncate(time.Minute))
+		}
+	}
+
+	return CronExpressions{Open: cronExpressions(opens), Close: cronExpressions(closes)}, nil
+}
+
+// cronExpressions returns the expressions firing at the given times in the week, grouping the
+// weekdays sharing the same time.
+func cronExpressions(offsets []time.Duration) []string {
+	var times []time.Duration
+	var weekdays [][]int
+	for _, offset := range offsets {
+		offset %= week
+		t := offset % day
+		i := slices.Index(times, t)
+		if i < 0 {
+			times = append(times, t)
+			weekdays = append(weekdays, nil)
+			i = len(times) - 1
+		}
+		// Cron counts the days of the week from sunday.
+		weekday := int((offset/day)+1) % 7
+		if !slices.Contains(weekdays[i], weekday) {
+			weekdays[i] = append(weekdays[i], weekday)
+		}
+	}
+
+	expressions := make([]string, 0, len(times))
+	for i, t := range times {
+		slices.Sort(weekdays[i])
+		expressions = append(expressions, fmt.Sprintf("%d %d * * %s", int(t%time.Hour/time.Minute), int(t/time.Hour), cronWeekdayList(weekdays[i])))
+	}
+
+	return expressions
+}
+
+// cronWeekdayList returns the sorted weekdays as a cron list, joining three or more consecutive
+// days into a range, eg. "0,2-4".
+func cronWeekdayList(weekdays []int) string {
+	if len(weekdays) == 7 {
+		return "*"
+	}
+
+	var items []string
+	for i := 0; i < len(weekdays); {
+		j := i
+		for j+1 < len(weekdays) && weekdays[j+1] == weekdays[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			items = append(items, fmt.Sprintf("%d-%d", weekdays[i], weekdays[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, strconv.Itoa(weekdays[k]))
+			}
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// ParseCronExpressions does the opposite of GetCronExpressions, returning the opening hours that
+// open whenever the open expression fires and close whenever the close expression fires. Firing
+// times at which the opening hours are already open, or already closed, are ignored. When both
+// expressions fire at the same time, the opening hours close then open again.
+//
+// The expressions use the five fields format, with lists, ranges, steps and names of weekdays, or
+// one of the @weekly, @daily and @hourly macros. An error is returned when an expression doesn't
+// recur every week, ie. when its day of month or month is not *, or when it is invalid.
+func ParseCronExpressions(open, close string) ([]OpeningHours, error) {
+	opens, err := parseCronExpression(open)
+	if err != nil {
+		return nil, fmt.Errorf("invalid open expression: %s", err)
+	}
+	closes, err := parseCronExpression(close)
+	if err != nil {
+		return nil, fmt.Errorf("invalid close expression: %s", err)
+	}
+
+	type event struct {
+		offset time.Duration
+		open   bool
+	}
+	// The events are repeated over three weeks so that the ranges of the middle week are known
+	// regardless of the ranges wrapping around the end of the week.
+	var events []event
+	for w := time.Duration(0); w < 3; w++ {
+		for _, offset := range closes {
+			events = append(events, event{offset: w*week + offset, open: false})
+		}
+		for _, offset := range opens {
+			events = append(events, event{offset: w*week + offset, open: true})
+		}
+	}
+	slices.SortStableFunc(events, func(a, b event) int { return cmp.Compare(a.offset, b.offset) })
+
+	var intervals []weekInterval
+	isOpen, start := false, time.Duration(0)
+	for _, e := range events {
+		switch {
+		case e.open && !isOpen:
+			isOpen, start = true, e.offset
+		case !e.open && isOpen:
+			isOpen = false
+			if start >= week && start < 2*week {
+				intervals = append(intervals, weekInterval{start: start - week, end: e.offset - week})
+			}
+		}
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+// parseCronExpression returns the times in the week, since monday 00:00, at which the expression
+// fires.
+func parseCronExpression(v string) ([]time.Duration, error) {
+	expression := strings.TrimSpace(v)
+	if strings.HasPrefix(expression, "@") {
+		macro, ok := cronMacros[expression]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cron expression `%s`: expected to recur every week", v)
+		}
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression `%s`: expected 5 fields", v)
+	}
+	if fields[2] != "*" || fields[3] != "*" {
+		return nil, fmt.Errorf("unsupported cron expression `%s`: expected day of month and month to be * to recur every week", v)
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute in cron expression `%s`: %s", v, err)
+	}
+	hours, err := parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour in cron expression `%s`: %s", v, err)
+	}
+	weekdays, err := parseCronField(fields[4], 0, 7, cronWeekdays[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid day of week in cron expression `%s`: %s", v, err)
+	}
+
+	var offsets []time.Duration
+	for _, weekday := range weekdays {
+		// Cron counts the days of the week from sunday, which is either 0 or 7.
+		midnight := time.Duration((weekday+6)%7) * day
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				offset := midnight + time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+				if !slices.Contains(offsets, offset) {
+					offsets = append(offsets, offset)
+				}
+			}
+		}
+	}
+	if len(offsets) == 0 {
+		return nil, fmt.Errorf("invalid cron expression `%s`: expected to fire", v)
+	}
+
+	return offsets, nil
+}
+
+// parseCronField returns the values of a cron field made of a comma-separated list of values,
+// ranges and steps, eg. "1-5" or "*/15". Names, if given, are the values starting from lo.
+func parseCronField(v string, lo, hi int, names []string) ([]int, error) {
+	value := func(s string) (int, error) {
+		if i := slices.IndexFunc(names, func(name string) bool { return strings.EqualFold(name, s) }); i >= 0 {
+			return lo + i, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < lo || n > hi {
+			return 0, fmt.Errorf("invalid value `%s`: expected to be between %d and %d", s, lo, hi)
+		}
+		return n, nil
+	}
+
+	var values []int
+	for _, item := range strings.Split(v, ",") {
+		r, s, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step `%s`: expected to be a positive number", s)
+			}
+			step = n
+		}
+
+		first, last := lo, hi
+		if r != "*" {
+			from, to, isRange := strings.Cut(r, "-")
+			var err error
+			if first, err = value(from); err != nil {
+				return nil, err
+			}
+			last = first
+			if isRange {
+				if last, err = value(to); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				last = hi
+			}
+			if last < first {
+				return nil, fmt.Errorf("invalid range `%s`: expected to be increasing", r)
+			}
+		}
+
+		for n := first; n <= last; n += step {
+			if !slices.Contains(values, n) {
+				values = append(values, n)
+			}
+		}
+	}
+
+	return values, nil
+}
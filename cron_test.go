@@ -0,0 +1,164 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCronExpressions(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult CronExpressions
+		expectedError  error
+	}{
+		"when workdays and saturday": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00"),
+			expectedResult: CronExpressions{
+				Open:  []string{"0 8 * * 1-5", "0 10 * * 6"},
+				Close: []string{"0 18 * * 1-5", "0 14 * * 6"},
+			},
+		},
+		"when every day with a break": {
+			openingHours: mustParseOpeningHours("W1T09:00:00/W1T12:30:00,W1T13:30:00/W1T17:00:00,W2T09:00:00/W2T12:30:00,W2T13:30:00/W2T17:00:00,W3T09:00:00/W3T12:30:00,W3T13:30:00/W3T17:00:00,W4T09:00:00/W4T12:30:00,W4T13:30:00/W4T17:00:00,W5T09:00:00/W5T12:30:00,W5T13:30:00/W5T17:00:00,W6T09:00:00/W6T12:30:00,W6T13:30:00/W6T17:00:00,W7T09:00:00/W7T12:30:00,W7T13:30:00/W7T17:00:00"),
+			expectedResult: CronExpressions{
+				Open:  []string{"0 9 * * *", "30 13 * * *"},
+				Close: []string{"30 12 * * *", "0 17 * * *"},
+			},
+		},
+		"when overnight around the end of the week": {
+			openingHours: mustParseOpeningHours("W5T22:00:00/W6T02:00:00,W7T22:00:00/W1T02:00:00,W2T20:00:00/W2T24:00:00,W4T20:00:00/W4T24:00:00"),
+			expectedResult: CronExpressions{
+				Open:  []string{"0 20 * * 2,4", "0 22 * * 0,5"},
+				Close: []string{"0 2 * * 1,6", "0 0 * * 3,5"},
+			},
+		},
+		"when seconds": {
+			openingHours: mustParseOpeningHours("W3T08:00:30/W3T16:00:15"),
+			expectedResult: CronExpressions{
+				Open:  []string{"0 8 * * 3"},
+				Close: []string{"1 16 * * 3"},
+			},
+		},
+		"when 24/7": {
+			openingHours:   []OpeningHours{TwentyFourSevenOH},
+			expectedResult: CronExpressions{},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: CronExpressions{},
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetCronExpressions(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestParseCronExpressions(t *testing.T) {
+	tests := map[string]struct {
+		open           string
+		close          string
+		expectedResult string
+		expectedError  error
+	}{
+		"when workdays": {
+			open:           "0 8 * * 1-5",
+			close:          "30 17 * * MON-FRI",
+			expectedResult: "W1T08:00:00/W1T17:30:00,W2T08:00:00/W2T17:30:00,W3T08:00:00/W3T17:30:00,W4T08:00:00/W4T17:30:00,W5T08:00:00/W5T17:30:00",
+		},
+		"when overnight around the end of the week": {
+			open:           "0 22 * * 0",
+			close:          "0 6 * * 1",
+			expectedResult: "W7T22:00:00/W1T06:00:00",
+		},
+		"when closed by a single expression": {
+			open:           "0 9,14 * * sat",
+			close:          "@weekly",
+			expectedResult: "W6T09:00:00/W6T24:00:00",
+		},
+		"when opening while already open": {
+			open:           "0 8-20/12 * * *",
+			close:          "0 12 * * *",
+			expectedResult: "W1T20:00:00/W2T12:00:00,W2T20:00:00/W3T12:00:00,W3T20:00:00/W4T12:00:00,W4T20:00:00/W5T12:00:00,W5T20:00:00/W6T12:00:00,W6T20:00:00/W7T12:00:00,W7T20:00:00/W1T12:00:00",
+		},
+		"when closing and opening at the same time": {
+			open:           "0 8,12 * * 3",
+			close:          "0 12,18 * * 3",
+			expectedResult: "W3T08:00:00/W3T18:00:00",
+		},
+		"when sunday as 7": {
+			open:           "0 10 * * 7",
+			close:          "0 16 * * 0",
+			expectedResult: "W7T10:00:00/W7T16:00:00",
+		},
+		"when day of month": {
+			open:          "0 8 1 * *",
+			close:         "0 18 * * *",
+			expectedError: fmt.Errorf("invalid open expression: unsupported cron expression `0 8 1 * *`: expected day of month and month to be * to recur every week"),
+		},
+		"when monthly": {
+			open:          "0 8 * * *",
+			close:         "@monthly",
+			expectedError: fmt.Errorf("invalid close expression: unsupported cron expression `@monthly`: expected to recur every week"),
+		},
+		"when seconds field": {
+			open:          "0 0 8 * * *",
+			close:         "0 18 * * *",
+			expectedError: fmt.Errorf("invalid open expression: invalid cron expression `0 0 8 * * *`: expected 5 fields"),
+		},
+		"when hour invalid": {
+			open:          "0 24 * * *",
+			close:         "0 18 * * *",
+			expectedError: fmt.Errorf("invalid open expression: invalid hour in cron expression `0 24 * * *`: invalid value `24`: expected to be between 0 and 23"),
+		},
+		"when range decreasing": {
+			open:          "0 8 * * *",
+			close:         "0 18 * * 5-1",
+			expectedError: fmt.Errorf("invalid close expression: invalid day of week in cron expression `0 18 * * 5-1`: invalid range `5-1`: expected to be increasing"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseCronExpressions(tt.open, tt.close)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestCronExpressionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ohs := mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W6T10:00:00/W6T14:00:00")
+	expressions, err := GetCronExpressions(ohs)
+	assert.NoError(t, err)
+	assert.Len(t, expressions.Open, 2)
+
+	result, err := ParseCronExpressions(expressions.Open[0], expressions.Close[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00", OpeningHoursSliceToString(result))
+}
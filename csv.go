@@ -0,0 +1,250 @@
+package openinghours
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cell values of the open and close columns with a special meaning.
+const (
+	CSVClosed  = "closed"
+	CSVFullDay = "24h"
+)
+
+// CSVOptions configures the columns read by ReadCSV and written by WriteCSV.
+type CSVOptions struct {
+	// IDColumn, WeekdayColumn, OpenColumn and CloseColumn are the names of the columns in the
+	// header row. They default to station_id, weekday, open and close when empty.
+	IDColumn      string
+	WeekdayColumn string
+	OpenColumn    string
+	CloseColumn   string
+
+	// Comma is the field delimiter. Defaults to ',' when zero.
+	Comma rune
+}
+
+func (opts CSVOptions) withDefaults() CSVOptions {
+	if opts.IDColumn == "" {
+		opts.IDColumn = "station_id"
+	}
+	if opts.WeekdayColumn == "" {
+		opts.WeekdayColumn = "weekday"
+	}
+	if opts.OpenColumn == "" {
+		opts.OpenColumn = "open"
+	}
+	if opts.CloseColumn == "" {
+		opts.CloseColumn = "close"
+	}
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	return opts
+}
+
+// CSVSchedule holds the opening hours of a single id, eg. a station.
+type CSVSchedule struct {
+	ID           string
+	OpeningHours []OpeningHours
+}
+
+// CSVRowError is an error about a single row of a CSV file.
+type CSVRowError struct {
+	Row int // Line on which the row starts in the file, the header being on row 1.
+	Err error
+}
+
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// ReadCSV reads opening hours from a CSV file with a header row, one row per opening period, eg.
+//
+//	station_id,weekday,open,close
+//	NL-1,monday,08:00,18:00
+//	NL-1,friday,22:00,02:00
+//	NL-1,sunday,closed,closed
+//	NL-2,monday,24h,24h
+//
+// The rows are grouped by id into schedules, in the order in which the ids first appear. The
+// weekday is given as a name such as "monday" or "mon", or as a number from 1 (monday) to 7
+// (sunday). The open and close times are given as hh:mm, a close time at or before the open time
+// running overnight into the next day. A row with "closed" as open or close time adds nothing but
+// the id, and a row with "24h" as open or close time is open the whole day.
+//
+// Invalid rows are left out and reported in the returned errors, so that a few broken rows don't
+// abort the whole file. The returned error is set when the file can't be read or when a column is
+// missing.
+func ReadCSV(r io.Reader, opts CSVOptions) ([]CSVSchedule, []*CSVRowError, error) {
+	opts = opts.withDefaults()
+
+	reader := csv.NewReader(r)
+	reader.Comma = opts.Comma
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %s", err)
+	}
+	var columns [4]int
+	for i, name := range []string{opts.IDColumn, opts.WeekdayColumn, opts.OpenColumn, opts.CloseColumn} {
+		columns[i] = slices.IndexFunc(header, func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), name) })
+		if columns[i] < 0 {
+			return nil, nil, fmt.Errorf("missing column `%s`", name)
+		}
+	}
+
+	var ids []string
+	intervals := map[string][]weekInterval{}
+	var rowErrors []*CSVRowError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			parseErr, ok := err.(*csv.ParseError)
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid CSV: %s", err)
+			}
+			rowErrors = append(rowErrors, &CSVRowError{Row: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		row, _ := reader.FieldPos(0)
+
+		cell := func(column int) string {
+			if column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+		id := cell(columns[0])
+		if id == "" {
+			rowErrors = append(rowErrors, &CSVRowError{Row: row, Err: fmt.Errorf("missing %s", opts.IDColumn)})
+			continue
+		}
+
+		wi, open, err := parseCSVRow(cell(columns[1]), cell(columns[2]), cell(columns[3]))
+		if err != nil {
+			rowErrors = append(rowErrors, &CSVRowError{Row: row, Err: err})
+			continue
+		}
+
+		if _, ok := intervals[id]; !ok {
+			ids = append(ids, id)
+			intervals[id] = nil
+		}
+		if open {
+			intervals[id] = append(intervals[id], wi)
+		}
+	}
+
+	schedules := make([]CSVSchedule, 0, len(ids))
+	for _, id := range ids {
+		schedules = append(schedules, CSVSchedule{ID: id, OpeningHours: openingHoursFromIntervals(intervals[id])})
+	}
+
+	return schedules, rowErrors, nil
+}
+
+// parseCSVRow returns the interval covered by a row. The second return value is false when the
+// row is closed.
+func parseCSVRow(weekday, open, close string) (weekInterval, bool, error) {
+	w, err := ParseStringWeekdayToTimeWeekday(weekday)
+	if err != nil {
+		w, err = strconv.Atoi(weekday)
+		if err != nil || w < 1 || w > 7 {
+			return weekInterval{}, false, fmt.Errorf("invalid weekday `%s`: expected to be a name or between 1 (monday) and 7 (sunday)", weekday)
+		}
+	}
+
+	switch {
+	case strings.EqualFold(open, CSVClosed) || strings.EqualFold(close, CSVClosed):
+		return weekInterval{}, false, nil
+	case strings.EqualFold(open, CSVFullDay) || strings.EqualFold(close, CSVFullDay):
+		open, close = "00:00", "24:00"
+	}
+
+	begin, err := parseClockTime(open)
+	if err != nil {
+		return weekInterval{}, false, fmt.Errorf("invalid open: %s", err)
+	}
+	if begin == 1440 {
+		return weekInterval{}, false, fmt.Errorf("invalid open: expected to be before 24:00")
+	}
+	end, err := parseClockTime(close)
+	if err != nil {
+		return weekInterval{}, false, fmt.Errorf("invalid close: %s", err)
+	}
+	if end <= begin {
+		end += 1440
+	}
+
+	midnight := time.Duration(w-1) * day
+
+	return weekInterval{start: midnight + time.Duration(begin)*time.Minute, end: midnight + time.Duration(end)*time.Minute}, true, nil
+}
+
+// WriteCSV does the opposite of ReadCSV, writing a header row and one row per day and opening
+// period of each schedule. Ranges running past midnight are split at midnight, full days are
+// written as "24h" and days without opening periods as "closed", so that every schedule has at
+// least a row for each day.
+//
+// Like GetOCPIOpeningTimes, open times are rounded down and close times are rounded up to the
+// minute. An error is returned when any of the opening hours is invalid, before anything is
+// written.
+func WriteCSV(w io.Writer, schedules []CSVSchedule, opts CSVOptions) error {
+	opts = opts.withDefaults()
+
+	for _, s := range schedules {
+		for _, oh := range s.OpeningHours {
+			if err := oh.Validate(); err != nil {
+				return fmt.Errorf("invalid schedule `%s`: %s", s.ID, err)
+			}
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.Comma
+	if err := writer.Write([]string{opts.IDColumn, opts.WeekdayColumn, opts.OpenColumn, opts.CloseColumn}); err != nil {
+		return err
+	}
+
+	for _, s := range schedules {
+		for weekday, periods := range dailyPeriods(s.OpeningHours) {
+			name := getWeekDay(weekday + 1)
+			if len(periods) == 0 {
+				if err := writer.Write([]string{s.ID, name, CSVClosed, CSVClosed}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for _, p := range periods {
+				row := []string{s.ID, name, CSVFullDay, CSVFullDay}
+				if begin, end := p.minutes(); begin != 0 || end != 1440 {
+					row[2], row[3] = minutesSinceMidnightToTime(begin), minutesSinceMidnightToTime(end)
+				}
+				if err := writer.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,178 @@
+package openinghours
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := map[string]struct {
+		data              string
+		opts              CSVOptions
+		expectedSchedules map[string]string
+		expectedIDs       []string
+		expectedErrors    []string
+		expectedError     error
+	}{
+		"when regular, overnight, closed and full days": {
+			data: "station_id,weekday,open,close\n" +
+				"NL-1,monday,08:00,18:00\n" +
+				"NL-1,Tue,08:00,12:00\n" +
+				"NL-1,tue,13:00,18:00\n" +
+				"NL-2,7,22:00,02:00\n" +
+				"NL-1,friday,22:00,24:00\n" +
+				"NL-1,saturday,24h,\n" +
+				"NL-3,sunday,closed,closed\n",
+			expectedIDs: []string{"NL-1", "NL-2", "NL-3"},
+			expectedSchedules: map[string]string{
+				"NL-1": "W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T12:00:00,W2T13:00:00/W2T18:00:00,W5T22:00:00/W6T24:00:00",
+				"NL-2": "W7T22:00:00/W1T02:00:00",
+				"NL-3": "",
+			},
+		},
+		"when custom columns": {
+			data: "site;day;from;to;comment\n" +
+				"A;wed;09:00;17:00;lunch break not included\n",
+			opts:              CSVOptions{IDColumn: "site", WeekdayColumn: "day", OpenColumn: "from", CloseColumn: "to", Comma: ';'},
+			expectedIDs:       []string{"A"},
+			expectedSchedules: map[string]string{"A": "W3T09:00:00/W3T17:00:00"},
+		},
+		"when invalid rows": {
+			data: "station_id,weekday,open,close\n" +
+				"NL-1,monday,08:00,18:00\n" +
+				"NL-1,someday,08:00,18:00\n" +
+				",tuesday,08:00,18:00\n" +
+				"NL-1,wednesday,8h,18:00\n" +
+				"NL-1,thursday,24:00,02:00\n" +
+				"NL-1,friday,08:00,18:60\n" +
+				"NL-1,\"saturday,08:00,18:00\n",
+			expectedIDs:       []string{"NL-1"},
+			expectedSchedules: map[string]string{"NL-1": "W1T08:00:00/W1T18:00:00"},
+			expectedErrors: []string{
+				"row 3: invalid weekday `someday`: expected to be a name or between 1 (monday) and 7 (sunday)",
+				"row 4: missing station_id",
+				"row 5: invalid open: invalid time `8h`: expected to be formatted as hh:mm",
+				"row 6: invalid open: expected to be before 24:00",
+				"row 7: invalid close: invalid time `18:60`: invalid minutes value",
+				"row 8: extraneous or missing \" in quoted-field",
+			},
+		},
+		"when column missing": {
+			data:          "station_id,weekday,opens,close\n",
+			expectedError: fmt.Errorf("missing column `open`"),
+		},
+		"when empty": {
+			data:          "",
+			expectedError: fmt.Errorf("invalid CSV: EOF"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schedules, rowErrors, err := ReadCSV(strings.NewReader(tt.data), tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+
+			var ids []string
+			for _, s := range schedules {
+				ids = append(ids, s.ID)
+				assert.Equal(t, tt.expectedSchedules[s.ID], OpeningHoursSliceToString(s.OpeningHours))
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+
+			var errs []string
+			for _, e := range rowErrors {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(t, tt.expectedErrors, errs)
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := map[string]struct {
+		schedules      []CSVSchedule
+		opts           CSVOptions
+		expectedResult string
+		expectedError  error
+	}{
+		"when regular, overnight and full days": {
+			schedules: []CSVSchedule{
+				{ID: "NL-1", OpeningHours: mustParseOpeningHours("W1T08:00:00/W1T12:00:00,W1T13:00:00/W1T18:00:00,W5T22:00:00/W6T02:00:00,W7T00:00:00/W7T24:00:00")},
+			},
+			expectedResult: "station_id,weekday,open,close\n" +
+				"NL-1,monday,08:00,12:00\n" +
+				"NL-1,monday,13:00,18:00\n" +
+				"NL-1,tuesday,closed,closed\n" +
+				"NL-1,wednesday,closed,closed\n" +
+				"NL-1,thursday,closed,closed\n" +
+				"NL-1,friday,22:00,24:00\n" +
+				"NL-1,saturday,00:00,02:00\n" +
+				"NL-1,sunday,24h,24h\n",
+		},
+		"when custom columns": {
+			schedules: []CSVSchedule{
+				{ID: "A", OpeningHours: []OpeningHours{TwentyFourSevenOH}},
+			},
+			opts: CSVOptions{IDColumn: "site", Comma: ';'},
+			expectedResult: "site;weekday;open;close\n" +
+				"A;monday;24h;24h\n" +
+				"A;tuesday;24h;24h\n" +
+				"A;wednesday;24h;24h\n" +
+				"A;thursday;24h;24h\n" +
+				"A;friday;24h;24h\n" +
+				"A;saturday;24h;24h\n" +
+				"A;sunday;24h;24h\n",
+		},
+		"when invalid": {
+			schedules: []CSVSchedule{
+				{ID: "NL-1", OpeningHours: []OpeningHours{{
+					Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+					Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+				}}},
+			},
+			expectedError: fmt.Errorf("invalid schedule `NL-1`: invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			err := WriteCSV(&buf, tt.schedules, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, buf.String())
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	schedules := []CSVSchedule{
+		{ID: "NL-1", OpeningHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W5T22:00:00/W6T02:00:00,W7T22:00:00/W1T06:00:00")},
+		{ID: "NL-2", OpeningHours: []OpeningHours{}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, schedules, CSVOptions{}))
+
+	result, rowErrors, err := ReadCSV(&buf, CSVOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, result, 2)
+	for i := range min(len(result), len(schedules)) {
+		assert.Equal(t, schedules[i].ID, result[i].ID)
+		assert.Equal(t, OpeningHoursSliceToString(schedules[i].OpeningHours), OpeningHoursSliceToString(result[i].OpeningHours))
+	}
+}
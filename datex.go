@@ -0,0 +1,139 @@
+package openinghours
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Namespaces of the DATEX II v3 schemas used by DATEXOperatingHours. The elements are marshaled
+// with the com, fac and xsi prefixes, which must be declared on an enclosing element, usually the
+// payload publication.
+const (
+	DATEXNamespaceCommon     = "http://datex2.eu/schema/3/common"
+	DATEXNamespaceFacilities = "http://datex2.eu/schema/3/facilities"
+	XSINamespace             = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// Types of the DATEX II operating hours.
+const (
+	DATEXOpenAllHours = "fac:OpenAllHours"
+	DATEXOpeningTimes = "fac:OpeningTimes"
+)
+
+// datexDays are the values of the DATEX II Day enum, monday first.
+var datexDays = [...]string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// DATEXOperatingHours represents the operatingHours of a DATEX II v3 facility, such as an energy
+// infrastructure site or a refill point published under AFIR.
+type DATEXOperatingHours struct {
+	XMLName      xml.Name            `xml:"fac:operatingHours"`
+	Type         string              `xml:"xsi:type,attr"` // fac:OpenAllHours or fac:OpeningTimes
+	OpeningTimes *DATEXOverallPeriod `xml:"fac:openingTimes,omitempty"`
+}
+
+// DATEXOverallPeriod represents the OverallPeriod class of DATEX II v3: the opening times apply
+// during any of the valid periods from overallStartTime.
+type DATEXOverallPeriod struct {
+	OverallStartTime time.Time     `xml:"com:overallStartTime"`
+	ValidPeriod      []DATEXPeriod `xml:"com:validPeriod"`
+}
+
+// DATEXPeriod represents the Period class of DATEX II v3: the period recurs during any of the
+// times of day, on any of the days.
+type DATEXPeriod struct {
+	RecurringTimePeriodOfDay    []DATEXTimePeriodByHour `xml:"com:recurringTimePeriodOfDay"`
+	RecurringDayWeekMonthPeriod []DATEXDayWeekMonth     `xml:"com:recurringDayWeekMonthPeriod"`
+}
+
+type DATEXTimePeriodByHour struct {
+	Type              string `xml:"xsi:type,attr"` // Always com:TimePeriodByHour
+	StartTimeOfPeriod string `xml:"com:startTimeOfPeriod" example:"08:00:00"`
+	EndTimeOfPeriod   string `xml:"com:endTimeOfPeriod" example:"18:00:00"` // "24:00:00" signals that the period runs until midnight at the end of the day.
+}
+
+type DATEXDayWeekMonth struct {
+	ApplicableDay []string `xml:"com:applicableDay" example:"monday"`
+}
+
+// GetDATEXOperatingHours returns the DATEX II v3 operating hours corresponding to the given
+// opening hours, applying from overallStartTime. If the opening hours are 24/7, their type is
+// fac:OpenAllHours. Otherwise their type is fac:OpeningTimes, with ranges running past midnight
+// being split at midnight and days with the same periods being grouped into a single valid period.
+// Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00,...,W5T08:00:00/W5T18:00:00")
+//	operatingHours, _ := GetDATEXOperatingHours(ohs, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+//	data, _ := xml.Marshal(operatingHours)
+//	// data will be:
+//	<fac:operatingHours xsi:type="fac:OpeningTimes">
+//	  <fac:openingTimes>
+//	    <com:overallStartTime>2026-01-01T00:00:00Z</com:overallStartTime>
+//	    <com:validPeriod>
+//	      <com:recurringTimePeriodOfDay xsi:type="com:TimePeriodByHour">
+//	        <com:startTimeOfPeriod>08:00:00</com:startTimeOfPeriod>
+//	        <com:endTimeOfPeriod>18:00:00</com:endTimeOfPeriod>
+//	      </com:recurringTimePeriodOfDay>
+//	      <com:recurringDayWeekMonthPeriod>
+//	        <com:applicableDay>monday</com:applicableDay>
+//	        ...
+//	        <com:applicableDay>friday</com:applicableDay>
+//	      </com:recurringDayWeekMonthPeriod>
+//	    </com:validPeriod>
+//	  </fac:openingTimes>
+//	</fac:operatingHours>
+//
+// An error is returned when any of the opening hours is invalid, or when they are never open as
+// an overall period without valid period would apply all the time.
+func GetDATEXOperatingHours(ohs []OpeningHours, overallStartTime time.Time) (DATEXOperatingHours, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return DATEXOperatingHours{}, err
+		}
+	}
+
+	if isTwentyFourSeven(ohs) {
+		return DATEXOperatingHours{Type: DATEXOpenAllHours}, nil
+	}
+
+	var validPeriods []DATEXPeriod
+	var periodDays [][]dayPeriod
+	for weekday, periods := range dailyPeriods(ohs) {
+		if len(periods) == 0 {
+			continue
+		}
+
+		i := slices.IndexFunc(periodDays, func(p []dayPeriod) bool { return slices.Equal(p, periods) })
+		if i < 0 {
+			var times []DATEXTimePeriodByHour
+			for _, p := range periods {
+				times = append(times, DATEXTimePeriodByHour{
+					Type:              "com:TimePeriodByHour",
+					StartTimeOfPeriod: timeOfDay(p.start),
+					EndTimeOfPeriod:   timeOfDay(p.end),
+				})
+			}
+			periodDays = append(periodDays, periods)
+			validPeriods = append(validPeriods, DATEXPeriod{
+				RecurringTimePeriodOfDay:    times,
+				RecurringDayWeekMonthPeriod: []DATEXDayWeekMonth{{}},
+			})
+			i = len(validPeriods) - 1
+		}
+
+		days := &validPeriods[i].RecurringDayWeekMonthPeriod[0]
+		days.ApplicableDay = append(days.ApplicableDay, datexDays[weekday])
+	}
+	if len(validPeriods) == 0 {
+		return DATEXOperatingHours{}, fmt.Errorf("opening hours are never open: can't be expressed in DATEX II")
+	}
+
+	return DATEXOperatingHours{
+		Type: DATEXOpeningTimes,
+		OpeningTimes: &DATEXOverallPeriod{
+			OverallStartTime: overallStartTime,
+			ValidPeriod:      validPeriods,
+		},
+	}, nil
+}
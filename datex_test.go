@@ -0,0 +1,123 @@
+package openinghours
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDATEXOperatingHours(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult DATEXOperatingHours
+		expectedError  error
+	}{
+		"when 24/7": {
+			openingHours:   []OpeningHours{TwentyFourSevenOH},
+			expectedResult: DATEXOperatingHours{Type: "fac:OpenAllHours"},
+		},
+		"when workdays and weekend": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00,W7T10:00:00/W7T14:00:00"),
+			expectedResult: DATEXOperatingHours{
+				Type: "fac:OpeningTimes",
+				OpeningTimes: &DATEXOverallPeriod{
+					OverallStartTime: start,
+					ValidPeriod: []DATEXPeriod{
+						{
+							RecurringTimePeriodOfDay:    []DATEXTimePeriodByHour{{Type: "com:TimePeriodByHour", StartTimeOfPeriod: "08:00:00", EndTimeOfPeriod: "18:00:00"}},
+							RecurringDayWeekMonthPeriod: []DATEXDayWeekMonth{{ApplicableDay: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}}},
+						},
+						{
+							RecurringTimePeriodOfDay:    []DATEXTimePeriodByHour{{Type: "com:TimePeriodByHour", StartTimeOfPeriod: "10:00:00", EndTimeOfPeriod: "14:00:00"}},
+							RecurringDayWeekMonthPeriod: []DATEXDayWeekMonth{{ApplicableDay: []string{"saturday", "sunday"}}},
+						},
+					},
+				},
+			},
+		},
+		"when overnight around the end of the week": {
+			openingHours: mustParseOpeningHours("W7T22:00:00/W1T06:00:30"),
+			expectedResult: DATEXOperatingHours{
+				Type: "fac:OpeningTimes",
+				OpeningTimes: &DATEXOverallPeriod{
+					OverallStartTime: start,
+					ValidPeriod: []DATEXPeriod{
+						{
+							RecurringTimePeriodOfDay:    []DATEXTimePeriodByHour{{Type: "com:TimePeriodByHour", StartTimeOfPeriod: "00:00:00", EndTimeOfPeriod: "06:00:30"}},
+							RecurringDayWeekMonthPeriod: []DATEXDayWeekMonth{{ApplicableDay: []string{"monday"}}},
+						},
+						{
+							RecurringTimePeriodOfDay:    []DATEXTimePeriodByHour{{Type: "com:TimePeriodByHour", StartTimeOfPeriod: "22:00:00", EndTimeOfPeriod: "24:00:00"}},
+							RecurringDayWeekMonthPeriod: []DATEXDayWeekMonth{{ApplicableDay: []string{"sunday"}}},
+						},
+					},
+				},
+			},
+		},
+		"when never open": {
+			openingHours:  []OpeningHours{},
+			expectedError: fmt.Errorf("opening hours are never open: can't be expressed in DATEX II"),
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetDATEXOperatingHours(tt.openingHours, start)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestDATEXOperatingHoursXML(t *testing.T) {
+	tests := map[string]struct {
+		openingHours []OpeningHours
+		golden       string
+	}{
+		"when opening times": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W5T22:00:00/W6T02:00:00,W6T09:00:00/W6T17:00:00"),
+			golden:       "opening_times.xml",
+		},
+		"when open all hours": {
+			openingHours: []OpeningHours{TwentyFourSevenOH},
+			golden:       "open_all_hours.xml",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			golden, err := os.ReadFile(filepath.Join("testdata", "datex", tt.golden))
+			assert.NoError(t, err)
+
+			operatingHours, err := GetDATEXOperatingHours(tt.openingHours, time.Date(2026, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600)))
+			assert.NoError(t, err)
+
+			data, err := xml.MarshalIndent(operatingHours, "", "  ")
+			assert.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(string(golden)), string(data))
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// fhirDaysOfWeek are the codes of the FHIR days-of-week value set, monday first.
+var fhirDaysOfWeek = [...]string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// fhirEndOfDay is the time at which ranges running until midnight end, as FHIR times must be
+// before 24:00:00.
+const fhirEndOfDay = "23:59:59"
+
+// FHIRAvailableTime represents the availableTime element of the HealthcareService and
+// PractitionerRole resources of HL7 FHIR R4, also used by the Availability data type of FHIR R5.
+type FHIRAvailableTime struct {
+	DaysOfWeek         []string `json:"daysOfWeek,omitempty" example:"mon"`
+	AllDay             bool     `json:"allDay,omitempty" example:"false"`
+	AvailableStartTime string   `json:"availableStartTime,omitempty" example:"08:00:00"`
+	AvailableEndTime   string   `json:"availableEndTime,omitempty" example:"18:00:00"` // "23:59:59" signals that the time runs until midnight at the end of the day.
+}
+
+// FHIRHoursOfOperation represents the hoursOfOperation element of the Location resource of HL7
+// FHIR R4.
+type FHIRHoursOfOperation struct {
+	DaysOfWeek  []string `json:"daysOfWeek,omitempty" example:"mon"`
+	AllDay      bool     `json:"allDay,omitempty" example:"false"`
+	OpeningTime string   `json:"openingTime,omitempty" example:"08:00:00"`
+	ClosingTime string   `json:"closingTime,omitempty" example:"18:00:00"` // "23:59:59" signals that the location is open until midnight at the end of the day.
+}
+
+// GetFHIRAvailableTimes returns the FHIR available times corresponding to the given opening hours.
+// Ranges running past midnight are split at midnight, and ranges running until midnight end at
+// 23:59:59 as FHIR times must be before 24:00:00. Days sharing the same start and end time are
+// grouped into a single available time, and full days are allDay. Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W6T00:00:00/W6T24:00:00")
+//	availableTimes, _ := GetFHIRAvailableTimes(ohs)
+//	// availableTimes will be:
+//	[]FHIRAvailableTime{
+//	    {DaysOfWeek: []string{"mon", "tue"}, AvailableStartTime: "08:00:00", AvailableEndTime: "18:00:00"},
+//	    {DaysOfWeek: []string{"sat"}, AllDay: true},
+//	}
+//
+// An error is returned when any of the opening hours is invalid.
+func GetFHIRAvailableTimes(ohs []OpeningHours) ([]FHIRAvailableTime, error) {
+	windows, err := fhirWindows(ohs)
+	if err != nil {
+		return nil, err
+	}
+
+	availableTimes := make([]FHIRAvailableTime, 0, len(windows))
+	for _, w := range windows {
+		availableTimes = append(availableTimes, FHIRAvailableTime{
+			DaysOfWeek:         w.daysOfWeek,
+			AllDay:             w.allDay,
+			AvailableStartTime: w.start,
+			AvailableEndTime:   w.end,
+		})
+	}
+
+	return availableTimes, nil
+}
+
+// ParseFHIRAvailableTimes does the opposite of GetFHIRAvailableTimes. Available times without
+// start or end time start at 00:00:00 or end at midnight at the end of the day, and available times
+// ending at or before their start run overnight into the next day, eg. from 22:00:00 to 06:00:00.
+func ParseFHIRAvailableTimes(availableTimes []FHIRAvailableTime) ([]OpeningHours, error) {
+	var intervals []weekInterval
+	for i, at := range availableTimes {
+		w := fhirWindow{daysOfWeek: at.DaysOfWeek, allDay: at.AllDay, start: at.AvailableStartTime, end: at.AvailableEndTime}
+		wis, err := w.intervals()
+		if err != nil {
+			return nil, fmt.Errorf("invalid available time #%d: %s", i+1, err)
+		}
+		intervals = append(intervals, wis...)
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+// GetFHIRHoursOfOperation is like GetFHIRAvailableTimes, for the hoursOfOperation of a Location.
+func GetFHIRHoursOfOperation(ohs []OpeningHours) ([]FHIRHoursOfOperation, error) {
+	windows, err := fhirWindows(ohs)
+	if err != nil {
+		return nil, err
+	}
+
+	hoursOfOperation := make([]FHIRHoursOfOperation, 0, len(windows))
+	for _, w := range windows {
+		hoursOfOperation = append(hoursOfOperation, FHIRHoursOfOperation{
+			DaysOfWeek:  w.daysOfWeek,
+			AllDay:      w.allDay,
+			OpeningTime: w.start,
+			ClosingTime: w.end,
+		})
+	}
+
+	return hoursOfOperation, nil
+}
+
+// ParseFHIRHoursOfOperation is like ParseFHIRAvailableTimes, for the hoursOfOperation of a
+// Location.
+func ParseFHIRHoursOfOperation(hoursOfOperation []FHIRHoursOfOperation) ([]OpeningHours, error) {
+	var intervals []weekInterval
+	for i, hoo := range hoursOfOperation {
+		w := fhirWindow{daysOfWeek: hoo.DaysOfWeek, allDay: hoo.AllDay, start: hoo.OpeningTime, end: hoo.ClosingTime}
+		wis, err := w.intervals()
+		if err != nil {
+			return nil, fmt.Errorf("invalid hours of operation #%d: %s", i+1, err)
+		}
+		intervals = append(intervals, wis...)
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+// fhirWindow holds the fields shared by FHIRAvailableTime and FHIRHoursOfOperation.
+type fhirWindow struct {
+	daysOfWeek []string
+	allDay     bool
+	start      string
+	end        string
+}
+
+// fhirWindows returns the windows of the opening hours, grouping the days sharing the same start
+// and end time, see GetFHIRAvailableTimes.
+func fhirWindows(ohs []OpeningHours) ([]fhirWindow, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var windows []fhirWindow
+	for weekday, periods := range dailyPeriods(ohs) {
+		for _, p := range periods {
+			var w fhirWindow
+			if p.start == 0 && p.end == day {
+				w.allDay = true
+			} else {
+				w.start = fhirTime(p.start)
+				w.end = fhirTime(p.end)
+			}
+
+			i := slices.IndexFunc(windows, func(other fhirWindow) bool {
+				return other.allDay == w.allDay && other.start == w.start && other.end == w.end
+			})
+			if i < 0 {
+				windows = append(windows, w)
+				i = len(windows) - 1
+			}
+			windows[i].daysOfWeek = append(windows[i].daysOfWeek, fhirDaysOfWeek[weekday])
+		}
+	}
+
+	return windows, nil
+}
+
+// intervals returns the intervals in the week covered by the window.
+func (w fhirWindow) intervals() ([]weekInterval, error) {
+	if len(w.daysOfWeek) == 0 {
+		return nil, fmt.Errorf("missing days of week")
+	}
+
+	start, end := time.Duration(0), day
+	if !w.allDay {
+		if w.start != "" {
+			v, err := parseFHIRTime(w.start)
+			if err != nil {
+				return nil, err
+			}
+			start = v
+		}
+		if w.end != "" && w.end != fhirEndOfDay {
+			v, err := parseFHIRTime(w.end)
+			if err != nil {
+				return nil, err
+			}
+			end = v
+		}
+		if end <= start {
+			end += day
+		}
+	}
+
+	var intervals []weekInterval
+	for _, d := range w.daysOfWeek {
+		weekday := slices.Index(fhirDaysOfWeek[:], d)
+		if weekday < 0 {
+			return nil, fmt.Errorf("invalid day of week `%s`: expected to be mon, tue, wed, thu, fri, sat or sun", d)
+		}
+
+		midnight := time.Duration(weekday) * day
+		intervals = append(intervals, weekInterval{start: midnight + start, end: midnight + end})
+	}
+
+	return intervals, nil
+}
+
+// fhirTime formats the time elapsed since midnight as a FHIR time, midnight at the end of the day
+// being 23:59:59.
+func fhirTime(d time.Duration) string {
+	if d == day {
+		return fhirEndOfDay
+	}
+
+	return timeOfDay(d)
+}
+
+// parseFHIRTime returns the time elapsed since midnight of a FHIR time.
+func parseFHIRTime(v string) (time.Duration, error) {
+	t, err := time.Parse(time.TimeOnly, v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time `%s`: expected to be formatted as hh:mm:ss", v)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+}
@@ -0,0 +1,174 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFHIRAvailableTimes(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult []FHIRAvailableTime
+		expectedError  error
+	}{
+		"when weekdays and full saturday": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T12:30:00,W6T00:00:00/W6T24:00:00"),
+			expectedResult: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon", "tue"}, AvailableStartTime: "08:00:00", AvailableEndTime: "18:00:00"},
+				{DaysOfWeek: []string{"wed"}, AvailableStartTime: "08:00:00", AvailableEndTime: "12:30:00"},
+				{DaysOfWeek: []string{"sat"}, AllDay: true},
+			},
+		},
+		"when overnight": {
+			openingHours: mustParseOpeningHours("W5T20:00:00/W6T02:00:00,W7T20:00:00/W1T02:00:00"),
+			expectedResult: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon", "sat"}, AvailableStartTime: "00:00:00", AvailableEndTime: "02:00:00"},
+				{DaysOfWeek: []string{"fri", "sun"}, AvailableStartTime: "20:00:00", AvailableEndTime: "23:59:59"},
+			},
+		},
+		"when seconds": {
+			openingHours: mustParseOpeningHours("W4T08:00:30/W4T16:00:15"),
+			expectedResult: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"thu"}, AvailableStartTime: "08:00:30", AvailableEndTime: "16:00:15"},
+			},
+		},
+		"when 24/7": {
+			openingHours: []OpeningHours{TwentyFourSevenOH},
+			expectedResult: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}, AllDay: true},
+			},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: []FHIRAvailableTime{},
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetFHIRAvailableTimes(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestParseFHIRAvailableTimes(t *testing.T) {
+	tests := map[string]struct {
+		availableTimes []FHIRAvailableTime
+		expectedResult string
+		expectedError  error
+	}{
+		"when weekdays and full saturday": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon", "tue"}, AvailableStartTime: "08:00:00", AvailableEndTime: "18:00:00"},
+				{DaysOfWeek: []string{"sat"}, AllDay: true, AvailableStartTime: "10:00:00"},
+			},
+			expectedResult: "W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W6T00:00:00/W6T24:00:00",
+		},
+		"when split at midnight": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"fri"}, AvailableStartTime: "20:00:00", AvailableEndTime: "23:59:59"},
+				{DaysOfWeek: []string{"sat"}, AvailableStartTime: "00:00:00", AvailableEndTime: "02:00:00"},
+			},
+			expectedResult: "W5T20:00:00/W6T02:00:00",
+		},
+		"when overnight": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"sun"}, AvailableStartTime: "22:00:00", AvailableEndTime: "06:00:00"},
+			},
+			expectedResult: "W7T22:00:00/W1T06:00:00",
+		},
+		"when start and end time missing": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"wed"}, AvailableEndTime: "12:00:00"},
+				{DaysOfWeek: []string{"thu"}, AvailableStartTime: "12:00:00"},
+			},
+			expectedResult: "W3T00:00:00/W3T12:00:00,W4T12:00:00/W4T24:00:00",
+		},
+		"when days of week missing": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon"}, AllDay: true},
+				{AllDay: true},
+			},
+			expectedError: fmt.Errorf("invalid available time #2: missing days of week"),
+		},
+		"when day of week invalid": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"monday"}, AllDay: true},
+			},
+			expectedError: fmt.Errorf("invalid available time #1: invalid day of week `monday`: expected to be mon, tue, wed, thu, fri, sat or sun"),
+		},
+		"when time invalid": {
+			availableTimes: []FHIRAvailableTime{
+				{DaysOfWeek: []string{"mon"}, AvailableStartTime: "08:00", AvailableEndTime: "18:00:00"},
+			},
+			expectedError: fmt.Errorf("invalid available time #1: invalid time `08:00`: expected to be formatted as hh:mm:ss"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseFHIRAvailableTimes(tt.availableTimes)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestFHIRHoursOfOperation(t *testing.T) {
+	t.Parallel()
+
+	var location struct {
+		ResourceType     string                 `json:"resourceType"`
+		HoursOfOperation []FHIRHoursOfOperation `json:"hoursOfOperation"`
+	}
+	err := json.Unmarshal([]byte(`{
+		"resourceType": "Location",
+		"hoursOfOperation": [
+			{"daysOfWeek": ["mon", "tue", "wed", "thu", "fri"], "openingTime": "08:00:00", "closingTime": "17:30:00"},
+			{"daysOfWeek": ["sat"], "allDay": true}
+		]
+	}`), &location)
+	assert.NoError(t, err)
+
+	ohs, err := ParseFHIRHoursOfOperation(location.HoursOfOperation)
+	assert.NoError(t, err)
+	assert.Equal(t, "W1T08:00:00/W1T17:30:00,W2T08:00:00/W2T17:30:00,W3T08:00:00/W3T17:30:00,W4T08:00:00/W4T17:30:00,W5T08:00:00/W5T17:30:00,W6T00:00:00/W6T24:00:00", OpeningHoursSliceToString(ohs))
+
+	location.HoursOfOperation, err = GetFHIRHoursOfOperation(ohs)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(location)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"resourceType": "Location",
+		"hoursOfOperation": [
+			{"daysOfWeek": ["mon", "tue", "wed", "thu", "fri"], "openingTime": "08:00:00", "closingTime": "17:30:00"},
+			{"daysOfWeek": ["sat"], "allDay": true}
+		]
+	}`, string(data))
+
+	_, err = ParseFHIRHoursOfOperation([]FHIRHoursOfOperation{{DaysOfWeek: []string{"mon"}, OpeningTime: "25:00:00"}})
+	assert.Equal(t, fmt.Errorf("invalid hours of operation #1: invalid time `25:00:00`: expected to be formatted as hh:mm:ss"), err)
+}
@@ -0,0 +1,433 @@
+package openinghours
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+	"slices"
+)
+
+// Formats in which RewriteGeoJSON writes the opening hours of the features.
+const (
+	GeoJSONFormatWString = "wstring" // eg. "W1T08:00:00/W1T18:00:00"
+	GeoJSONFormatOSM     = "osm"     // eg. "Mo 08:00-18:00"
+	GeoJSONFormatOCPI    = "ocpi"    // the OCPI 3.0 Hours object
+)
+
+// GeoJSONOptions configures how the opening hours of GeoJSON features are read and written.
+type GeoJSONOptions struct {
+	// Property is the name of the feature property holding the opening hours. Defaults to
+	// "opening_hours" when empty.
+	Property string
+
+	// Format is the format in which RewriteGeoJSON writes the opening hours. Defaults to
+	// GeoJSONFormatWString when empty.
+	Format string
+}
+
+func (opts GeoJSONOptions) withDefaults() GeoJSONOptions {
+	if opts.Property == "" {
+		opts.Property = "opening_hours"
+	}
+	if opts.Format == "" {
+		opts.Format = GeoJSONFormatWString
+	}
+
+	return opts
+}
+
+// GeoJSONFeature is a feature of a GeoJSON FeatureCollection along with its opening hours.
+type GeoJSONFeature struct {
+	// Index is the position of the feature in the collection, starting at 0.
+	Index int
+
+	// ID is the id of the feature as found in the document, eg. `"node/42"` or `42`, or nil.
+	ID json.RawMessage
+
+	// OpeningHours are the opening hours of the feature. HasOpeningHours is false when the
+	// feature doesn't have the property.
+	OpeningHours    []OpeningHours
+	HasOpeningHours bool
+
+	// members are the members of the feature object, in document order.
+	members []jsonMember
+}
+
+// GeoJSONFeatureError is an error about a single feature of a GeoJSON FeatureCollection.
+type GeoJSONFeatureError struct {
+	Index int
+	ID    json.RawMessage
+	Err   error
+}
+
+func (e *GeoJSONFeatureError) Error() string {
+	if len(e.ID) > 0 {
+		return fmt.Sprintf("feature #%d (id %s): %s", e.Index, e.ID, e.Err)
+	}
+
+	return fmt.Sprintf("feature #%d: %s", e.Index, e.Err)
+}
+
+func (e *GeoJSONFeatureError) Unwrap() error {
+	return e.Err
+}
+
+// jsonMember is a member of a JSON object.
+type jsonMember struct {
+	name  string
+	value json.RawMessage
+}
+
+// wStringPattern matches the opening hours values using the W-string format of this package
+// rather than the OpenStreetMap syntax.
+var wStringPattern = regexp.MustCompile(`^\s*/?W\d`)
+
+// ReadGeoJSON streams the features of a GeoJSON FeatureCollection, parsing the opening hours of
+// each of them. The features are read one at a time, so that large files don't have to fit in
+// memory.
+//
+// The opening hours may be given as a W-string, in the OpenStreetMap syntax supported by
+// ParseOSMOpeningHours, or as an OCPI 3.0 Hours object. When the opening hours of a feature are
+// invalid, the feature is yielded with a *GeoJSONFeatureError and the iteration goes on with the
+// next feature. Any other error means that the document is not a valid FeatureCollection, and ends
+// the iteration.
+func ReadGeoJSON(r io.Reader, opts GeoJSONOptions) iter.Seq2[GeoJSONFeature, error] {
+	opts = opts.withDefaults()
+
+	return func(yield func(GeoJSONFeature, error) bool) {
+		err := decodeFeatureCollection(r, func(string, json.RawMessage) error { return nil }, func(index int, raw json.RawMessage) error {
+			feature, err := parseGeoJSONFeature(index, raw, opts)
+			if err != nil {
+				if _, ok := err.(*GeoJSONFeatureError); !ok {
+					return err
+				}
+			}
+			if !yield(feature, err) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(GeoJSONFeature{}, err)
+		}
+	}
+}
+
+// RewriteGeoJSON copies a GeoJSON FeatureCollection from r to w, rewriting the opening hours of
+// each feature in opts.Format. Like ReadGeoJSON, the features are processed one at a time.
+//
+// Features whose opening hours are invalid are copied unchanged and reported in the returned
+// errors, so that a few broken features don't abort the whole file. Features without opening
+// hours are copied unchanged. The returned error is set when the document is not a valid
+// FeatureCollection or can't be written, in which case w holds a partial document.
+func RewriteGeoJSON(w io.Writer, r io.Reader, opts GeoJSONOptions) ([]*GeoJSONFeatureError, error) {
+	opts = opts.withDefaults()
+	if opts.Format != GeoJSONFormatWString && opts.Format != GeoJSONFormatOSM && opts.Format != GeoJSONFormatOCPI {
+		return nil, fmt.Errorf("unsupported format `%s`: expected to be wstring, osm or ocpi", opts.Format)
+	}
+
+	var featureErrors []*GeoJSONFeatureError
+	var buf bytes.Buffer
+	first, inFeatures := true, false
+
+	closeFeatures := func() {
+		if inFeatures {
+			buf.WriteString("]")
+			inFeatures = false
+		}
+	}
+	writeName := func(name string) {
+		if first {
+			buf.WriteString("{")
+			first = false
+		} else {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteString(":")
+	}
+	flush := func() error {
+		_, err := w.Write(buf.Bytes())
+		buf.Reset()
+		return err
+	}
+
+	err := decodeFeatureCollection(r,
+		func(name string, value json.RawMessage) error {
+			closeFeatures()
+			writeName(name)
+			if value == nil {
+				buf.WriteString("[")
+				inFeatures = true
+			} else {
+				buf.Write(value)
+			}
+			return flush()
+		},
+		func(index int, raw json.RawMessage) error {
+			if index > 0 {
+				buf.WriteString(",")
+			}
+
+			feature, err := parseGeoJSONFeature(index, raw, opts)
+			switch err := err.(type) {
+			case nil:
+			case *GeoJSONFeatureError:
+				featureErrors = append(featureErrors, err)
+				buf.Write(raw)
+				return flush()
+			default:
+				return err
+			}
+			if !feature.HasOpeningHours {
+				buf.Write(raw)
+				return flush()
+			}
+
+			rewritten, err := feature.rewrite(opts)
+			if err != nil {
+				featureErrors = append(featureErrors, &GeoJSONFeatureError{Index: index, ID: feature.ID, Err: err})
+				buf.Write(raw)
+				return flush()
+			}
+			buf.Write(rewritten)
+			return flush()
+		},
+	)
+	if err != nil {
+		return featureErrors, err
+	}
+
+	if first {
+		buf.WriteString("{")
+	}
+	closeFeatures()
+	buf.WriteString("}\n")
+
+	return featureErrors, flush()
+}
+
+// errStopIteration ends decodeFeatureCollection early when the caller stops iterating.
+var errStopIteration = errors.New("stop iteration")
+
+// decodeFeatureCollection streams the members of a FeatureCollection, calling member for each
+// member, and feature for each feature. For the features member, member is called with a nil value
+// before the first feature.
+func decodeFeatureCollection(r io.Reader, member func(string, json.RawMessage) error, feature func(int, json.RawMessage) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid GeoJSON: %s", err)
+		}
+		name, _ := token.(string)
+
+		if name != "features" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return fmt.Errorf("invalid GeoJSON: %s", err)
+			}
+			if name == "type" && string(value) != `"FeatureCollection"` {
+				return fmt.Errorf("invalid GeoJSON type %s: expected to be a FeatureCollection", value)
+			}
+			if err := member(name, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		if err := member(name, nil); err != nil {
+			return err
+		}
+		for index := 0; dec.More(); index++ {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return fmt.Errorf("invalid GeoJSON: %s", err)
+			}
+			if err := feature(index, raw); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid GeoJSON: %s", err)
+	}
+	if token != delim {
+		return fmt.Errorf("invalid GeoJSON: unexpected %v, expected `%s`", token, delim)
+	}
+
+	return nil
+}
+
+// parseGeoJSONFeature returns the feature with its opening hours. Invalid opening hours are
+// reported as a *GeoJSONFeatureError.
+func parseGeoJSONFeature(index int, raw json.RawMessage, opts GeoJSONOptions) (GeoJSONFeature, error) {
+	feature := GeoJSONFeature{Index: index}
+	fail := func(err error) (GeoJSONFeature, error) {
+		return feature, &GeoJSONFeatureError{Index: index, ID: feature.ID, Err: err}
+	}
+
+	members, err := decodeObject(raw)
+	if err != nil {
+		return fail(fmt.Errorf("expected to be an object"))
+	}
+	feature.members = members
+
+	var properties json.RawMessage
+	for _, m := range members {
+		switch m.name {
+		case "id":
+			feature.ID = m.value
+		case "properties":
+			properties = m.value
+		}
+	}
+	if len(properties) == 0 || string(properties) == "null" {
+		return feature, nil
+	}
+
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(properties, &props); err != nil {
+		return fail(fmt.Errorf("invalid properties: expected to be an object"))
+	}
+	value, ok := props[opts.Property]
+	if !ok || string(value) == "null" {
+		return feature, nil
+	}
+
+	ohs, err := parseGeoJSONOpeningHours(value)
+	if err != nil {
+		return fail(fmt.Errorf("invalid %s: %s", opts.Property, err))
+	}
+	feature.OpeningHours = ohs
+	feature.HasOpeningHours = true
+
+	return feature, nil
+}
+
+// parseGeoJSONOpeningHours parses opening hours given as a W-string, in the OpenStreetMap syntax
+// or as an OCPI 3.0 Hours object.
+func parseGeoJSONOpeningHours(value json.RawMessage) ([]OpeningHours, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if wStringPattern.MatchString(s) {
+			return ParseOpeningHours(s)
+		}
+		return ParseOSMOpeningHours(s)
+	}
+
+	var ocpi OCPIOpeningTimes
+	if err := json.Unmarshal(value, &ocpi); err != nil {
+		return nil, fmt.Errorf("expected to be a string or an OCPI Hours object")
+	}
+
+	return ParseOCPIOpeningTimes(ocpi)
+}
+
+// rewrite returns the feature with its opening hours in the given format, leaving the other
+// members and properties in place.
+func (f GeoJSONFeature) rewrite(opts GeoJSONOptions) ([]byte, error) {
+	var value any
+	switch opts.Format {
+	case GeoJSONFormatWString:
+		value = OpeningHoursSliceToString(f.OpeningHours)
+	case GeoJSONFormatOSM:
+		v, err := GetOSMOpeningHours(f.OpeningHours)
+		if err != nil {
+			return nil, err
+		}
+		value = v
+	case GeoJSONFormatOCPI:
+		v, err := GetOCPIOpeningTimes(f.OpeningHours)
+		if err != nil {
+			return nil, err
+		}
+		value = v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	members := slices.Clone(f.members)
+	for i, m := range members {
+		if m.name != "properties" {
+			continue
+		}
+
+		properties, err := decodeObject(m.value)
+		if err != nil {
+			return nil, err
+		}
+		for j, p := range properties {
+			if p.name == opts.Property {
+				properties[j].value = data
+			}
+		}
+		members[i].value = encodeObject(properties)
+	}
+
+	return encodeObject(members), nil
+}
+
+// decodeObject returns the members of a JSON object, in document order.
+func decodeObject(data json.RawMessage) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var members []jsonMember
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{name: token.(string), value: value})
+	}
+
+	return members, nil
+}
+
+// encodeObject does the opposite of decodeObject.
+func encodeObject(members []jsonMember) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(m.name)
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(m.value)
+	}
+	buf.WriteString("}")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,158 @@
+package openinghours
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testFeatureCollection = `{
+  "type": "FeatureCollection",
+  "name": "pois",
+  "features": [
+    {"type": "Feature", "id": "node/1", "geometry": {"type": "Point", "coordinates": [4.9, 52.37]}, "properties": {"name": "Bakery", "opening_hours": "Mo-Fr 08:00-18:00; Sa 08:00-12:00"}},
+    {"type": "Feature", "id": 2, "geometry": null, "properties": {"opening_hours": "W7T22:00:00/W1T02:00:00"}},
+    {"type": "Feature", "geometry": null, "properties": {"opening_hours": "Mo-Fr 08:00-18:00; PH off"}},
+    {"type": "Feature", "geometry": null, "properties": {"name": "Bench"}},
+    {"type": "Feature", "geometry": null, "properties": {"opening_hours": {"twentyfourseven": true}}}
+  ]
+}`
+
+func TestReadGeoJSON(t *testing.T) {
+	t.Parallel()
+
+	var results []string
+	var featureErrors []error
+	for feature, err := range ReadGeoJSON(strings.NewReader(testFeatureCollection), GeoJSONOptions{}) {
+		if err != nil {
+			featureErrors = append(featureErrors, err)
+			continue
+		}
+		results = append(results, fmt.Sprintf("%d %s %t %s", feature.Index, string(feature.ID), feature.HasOpeningHours, OpeningHoursSliceToString(feature.OpeningHours)))
+	}
+
+	assert.Equal(t, []string{
+		`0 "node/1" true W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T08:00:00/W6T12:00:00`,
+		`1 2 true W7T22:00:00/W1T02:00:00`,
+		`3  false `,
+		`4  true ` + TwentyFourSevenString,
+	}, results)
+	assert.Len(t, featureErrors, 1)
+	assert.EqualError(t, featureErrors[0], "feature #2: invalid opening_hours: invalid opening_hours rule `PH off`: unsupported time range `PH off`: expected to be formatted as hh:mm-hh:mm")
+
+	var featureErr *GeoJSONFeatureError
+	assert.True(t, errors.As(featureErrors[0], &featureErr))
+	assert.Equal(t, 2, featureErr.Index)
+}
+
+func TestReadGeoJSONWhenInvalidDocument(t *testing.T) {
+	tests := map[string]struct {
+		document      string
+		expectedCount int
+		expectedError error
+	}{
+		"when not a feature collection": {
+			document:      `{"type": "Feature", "properties": {}}`,
+			expectedError: fmt.Errorf(`invalid GeoJSON type "Feature": expected to be a FeatureCollection`),
+		},
+		"when truncated": {
+			document:      `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"opening_hours": "24/7"}}, {"type": `,
+			expectedCount: 1,
+			expectedError: fmt.Errorf("invalid GeoJSON: unexpected EOF"),
+		},
+		"when not an object": {
+			document:      `[]`,
+			expectedError: fmt.Errorf("invalid GeoJSON: unexpected [, expected `{`"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			count := 0
+			var lastErr error
+			for _, err := range ReadGeoJSON(strings.NewReader(tt.document), GeoJSONOptions{}) {
+				if err != nil {
+					lastErr = err
+					continue
+				}
+				count++
+			}
+			assert.Equal(t, tt.expectedCount, count)
+			assert.Equal(t, tt.expectedError, lastErr)
+		})
+	}
+}
+
+func TestRewriteGeoJSON(t *testing.T) {
+	tests := map[string]struct {
+		opts           GeoJSONOptions
+		expectedResult string
+	}{
+		"when W-string": {
+			opts: GeoJSONOptions{},
+			expectedResult: `{"type":"FeatureCollection","name":"pois","features":[` +
+				`{"type":"Feature","id":"node/1","geometry":{"type": "Point", "coordinates": [4.9, 52.37]},"properties":{"name":"Bakery","opening_hours":"W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T08:00:00/W6T12:00:00"}},` +
+				`{"type":"Feature","id":2,"geometry":null,"properties":{"opening_hours":"W7T22:00:00/W1T02:00:00"}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"opening_hours": "Mo-Fr 08:00-18:00; PH off"}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"name": "Bench"}},` +
+				`{"type":"Feature","geometry":null,"properties":{"opening_hours":"W1T00:00:00/W7T24:00:00"}}` +
+				"]}\n",
+		},
+		"when OpenStreetMap": {
+			opts: GeoJSONOptions{Format: GeoJSONFormatOSM},
+			expectedResult: `{"type":"FeatureCollection","name":"pois","features":[` +
+				`{"type":"Feature","id":"node/1","geometry":{"type": "Point", "coordinates": [4.9, 52.37]},"properties":{"name":"Bakery","opening_hours":"Mo-Fr 08:00-18:00; Sa 08:00-12:00"}},` +
+				`{"type":"Feature","id":2,"geometry":null,"properties":{"opening_hours":"Mo 00:00-02:00; Su 22:00-24:00"}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"opening_hours": "Mo-Fr 08:00-18:00; PH off"}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"name": "Bench"}},` +
+				`{"type":"Feature","geometry":null,"properties":{"opening_hours":"24/7"}}` +
+				"]}\n",
+		},
+		"when OCPI": {
+			opts: GeoJSONOptions{Format: GeoJSONFormatOCPI},
+			expectedResult: `{"type":"FeatureCollection","name":"pois","features":[` +
+				`{"type":"Feature","id":"node/1","geometry":{"type": "Point", "coordinates": [4.9, 52.37]},"properties":{"name":"Bakery","opening_hours":{"twentyfourseven":false,"regular_hours":[{"weekday":1,"period_begin":"08:00","period_end":"18:00"},{"weekday":2,"period_begin":"08:00","period_end":"18:00"},{"weekday":3,"period_begin":"08:00","period_end":"18:00"},{"weekday":4,"period_begin":"08:00","period_end":"18:00"},{"weekday":5,"period_begin":"08:00","period_end":"18:00"},{"weekday":6,"period_begin":"08:00","period_end":"12:00"}]}}},` +
+				`{"type":"Feature","id":2,"geometry":null,"properties":{"opening_hours":{"twentyfourseven":false,"regular_hours":[{"weekday":7,"period_begin":"22:00","period_end":"00:00"},{"weekday":1,"period_begin":"00:00","period_end":"02:00"}]}}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"opening_hours": "Mo-Fr 08:00-18:00; PH off"}},` +
+				`{"type": "Feature", "geometry": null, "properties": {"name": "Bench"}},` +
+				`{"type":"Feature","geometry":null,"properties":{"opening_hours":{"twentyfourseven":true}}}` +
+				"]}\n",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			featureErrors, err := RewriteGeoJSON(&buf, strings.NewReader(testFeatureCollection), tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, buf.String())
+			assert.Len(t, featureErrors, 1)
+			assert.Equal(t, 2, featureErrors[0].Index)
+		})
+	}
+}
+
+func TestRewriteGeoJSONWhenEmpty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	featureErrors, err := RewriteGeoJSON(&buf, strings.NewReader(`{"type": "FeatureCollection", "features": []}`), GeoJSONOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, featureErrors)
+	assert.Equal(t, "{\"type\":\"FeatureCollection\",\"features\":[]}\n", buf.String())
+
+	_, err = RewriteGeoJSON(&buf, strings.NewReader(`{}`), GeoJSONOptions{Format: "xml"})
+	assert.Equal(t, fmt.Errorf("unsupported format `xml`: expected to be wstring, osm or ocpi"), err)
+}
@@ -0,0 +1,677 @@
+package openinghours
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalDateTimeFormat = "20060102T150405"
+
+	// icalMaxLineLength is the maximum length of a content line in octets, excluding the line
+	// break, after which lines are folded.
+	icalMaxLineLength = 75
+)
+
+// icalWeekdays are the weekday codes used in recurrence rules, indexed by time.Weekday.
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ICalendarOptions configures the calendar produced by MarshalICalendar.
+type ICalendarOptions struct {
+	// Location is the time zone of the opening hours. It is required.
+	Location *time.Location
+
+	// Start is a time within the week in which the recurring events start.
+	Start time.Time
+
+	// Summary is the summary of the events, eg. the name of the site. Defaults to "Open".
+	Summary string
+
+	// ProductID is the identifier of the product that created the calendar. Defaults to
+	// "-//ParenInc//openinghours//EN".
+	ProductID string
+
+	// UIDDomain is appended to the unique identifiers of the events, eg. the identifier or the
+	// domain name of the site. Defaults to "openinghours".
+	UIDDomain string
+
+	// Timestamp is the time at which the calendar is created. Defaults to the current time.
+	Timestamp time.Time
+
+	// Closures are the dates on which the location is closed. Only their date is used: the
+	// occurrences starting on these dates are excluded from the events.
+	Closures []time.Time
+}
+
+// MarshalICalendar returns the opening hours as an iCalendar (RFC 5545), with one weekly recurring
+// event per range. Ranges running overnight or over several days are single events.
+//
+// The events are given in the time zone of the opening hours, which is described in a VTIMEZONE
+// component derived from the time zone rules around the year in which the events start.
+func MarshalICalendar(ohs []OpeningHours, opts ICalendarOptions) ([]byte, error) {
+	if opts.Location == nil {
+		return nil, fmt.Errorf("missing time zone")
+	}
+
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	opts = opts.withDefaults()
+	monday := startOfWeek(opts.Start, opts.Location)
+
+	var w icalWriter
+	w.begin(opts)
+	w.timezone(opts.Location, monday.Year())
+
+	for _, oh := range ohs {
+		wi, ok := oh.interval()
+		if !ok {
+			continue
+		}
+
+		start, end := monday.Add(wi.start), monday.Add(wi.end)
+
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", icalUID(oh, opts))
+		w.line("DTSTAMP", opts.Timestamp.UTC().Format(icalDateTimeFormat)+"Z")
+		w.occurrences(start, end, []time.Weekday{start.Weekday()}, opts)
+		w.line("SUMMARY", icalEscape(opts.Summary))
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.Bytes(), nil
+}
+
+// withDefaults returns the options with the defaults filled in.
+func (opts ICalendarOptions) withDefaults() ICalendarOptions {
+	if opts.Summary == "" {
+		opts.Summary = "Open"
+	}
+	if opts.ProductID == "" {
+		opts.ProductID = "-//ParenInc//openinghours//EN"
+	}
+	if opts.UIDDomain == "" {
+		opts.UIDDomain = "openinghours"
+	}
+	if opts.Timestamp.IsZero() {
+		opts.Timestamp = time.Now()
+	}
+
+	return opts
+}
+
+// icalUID returns the unique identifier of the component describing the opening hours.
+func icalUID(oh OpeningHours, opts ICalendarOptions) string {
+	return strings.NewReplacer("/", "-", ":", "").Replace(oh.String()) + "@" + opts.UIDDomain
+}
+
+// icalWriter writes iCalendar content lines, folding them when they are too long.
+type icalWriter struct {
+	bytes.Buffer
+}
+
+// begin writes the start of the calendar.
+func (w *icalWriter) begin(opts ICalendarOptions) {
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", opts.ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+}
+
+// occurrences writes the start, end and weekly recurrence of a component starting and ending at
+// the given wall clock times and recurring on the given weekdays, leaving out the occurrences
+// starting on the closure dates.
+func (w *icalWriter) occurrences(start, end time.Time, weekdays []time.Weekday, opts ICalendarOptions) {
+	w.dateTime("DTSTART", start, opts.Location)
+	w.dateTime("DTEND", end, opts.Location)
+
+	byDay := make([]string, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		byDay = append(byDay, icalWeekdays[weekday])
+	}
+	w.line("RRULE", "FREQ=WEEKLY;BYDAY="+strings.Join(byDay, ","))
+
+	var exdates []string
+	for _, closure := range opts.Closures {
+		date := time.Date(closure.Year(), closure.Month(), closure.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+		if slices.Contains(weekdays, date.Weekday()) && !date.Before(start) {
+			exdates = append(exdates, date.Format(icalDateTimeFormat))
+		}
+	}
+	if len(exdates) > 0 {
+		w.dateTime("EXDATE", time.Time{}, opts.Location, exdates...)
+	}
+}
+
+func (w *icalWriter) line(name, value string) {
+	line := name + ":" + value
+	for len(line) > icalMaxLineLength {
+		i := icalMaxLineLength
+		for !utf8.RuneStart(line[i]) {
+			i--
+		}
+		w.WriteString(line[:i] + "\r\n")
+		line = " " + line[i:]
+	}
+	w.WriteString(line + "\r\n")
+}
+
+// dateTime writes a date-time property for the given wall clock time in the given location. When
+// values are given, they are written instead of the wall clock time.
+func (w *icalWriter) dateTime(name string, wall time.Time, loc *time.Location, values ...string) {
+	if len(values) == 0 {
+		values = []string{wall.Format(icalDateTimeFormat)}
+	}
+
+	if loc == time.UTC {
+		for i := range values {
+			values[i] += "Z"
+		}
+		w.line(name, strings.Join(values, ","))
+		return
+	}
+
+	w.line(name+";TZID="+loc.String(), strings.Join(values, ","))
+}
+
+// timezone writes a VTIMEZONE component describing the location, based on its transitions during
+// the year before the given year, so that the whole given year is covered. Transitions that
+// happen on the same weekday of the month the following year are written as yearly recurring.
+// Nothing is written for UTC, as UTC times need no time zone.
+func (w *icalWriter) timezone(loc *time.Location, year int) {
+	if loc == time.UTC {
+		return
+	}
+	year--
+
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+
+	transitions := zoneTransitions(loc, year)
+	if len(transitions) == 0 {
+		t := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+		name, offset := t.Zone()
+
+		w.line("BEGIN", "STANDARD")
+		w.line("DTSTART", "19700101T000000")
+		w.line("TZOFFSETFROM", icalOffset(offset))
+		w.line("TZOFFSETTO", icalOffset(offset))
+		w.line("TZNAME", name)
+		w.line("END", "STANDARD")
+	}
+
+	next := zoneTransitions(loc, year+1)
+	for _, tr := range transitions {
+		component := "STANDARD"
+		if tr.In(loc).IsDST() {
+			component = "DAYLIGHT"
+		}
+		_, from := tr.Add(-time.Second).In(loc).Zone()
+		name, to := tr.In(loc).Zone()
+
+		// The start of the observance is given in the local time before the transition.
+		wall := tr.UTC().Add(time.Duration(from) * time.Second)
+
+		w.line("BEGIN", component)
+		w.line("DTSTART", wall.Format(icalDateTimeFormat))
+		if rule, ok := icalYearlyRule(wall, from, to, next, loc); ok {
+			w.line("RRULE", rule)
+		}
+		w.line("TZOFFSETFROM", icalOffset(from))
+		w.line("TZOFFSETTO", icalOffset(to))
+		w.line("TZNAME", name)
+		w.line("END", component)
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// zoneTransitions returns the instants at which the offset of the location changes during the
+// given year.
+func zoneTransitions(loc *time.Location, year int) []time.Time {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+
+	var transitions []time.Time
+	for t := start; ; {
+		_, zoneEnd := t.ZoneBounds()
+		if zoneEnd.IsZero() || !zoneEnd.Before(end) {
+			return transitions
+		}
+		transitions = append(transitions, zoneEnd)
+		t = zoneEnd
+	}
+}
+
+// icalYearlyRule returns the yearly recurrence rule of a transition happening at the given wall
+// clock time, eg. "on the last sunday of march", provided that the rule matches one of the given
+// transitions of the next year.
+func icalYearlyRule(wall time.Time, from, to int, next []time.Time, loc *time.Location) (string, bool) {
+	daysInMonth := time.Date(wall.Year(), wall.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	nth := (wall.Day()-1)/7 + 1
+	if wall.Day()+7 > daysInMonth {
+		nth = -1
+	}
+
+	for _, tr := range next {
+		_, nextFrom := tr.Add(-time.Second).In(loc).Zone()
+		_, nextTo := tr.In(loc).Zone()
+		nextWall := tr.UTC().Add(time.Duration(nextFrom) * time.Second)
+		if nextFrom != from || nextTo != to || nextWall.Month() != wall.Month() || nextWall.Weekday() != wall.Weekday() {
+			continue
+		}
+		if nextWall.Hour() != wall.Hour() || nextWall.Minute() != wall.Minute() {
+			continue
+		}
+
+		daysInNextMonth := time.Date(nextWall.Year(), nextWall.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if (nth == -1 && nextWall.Day()+7 > daysInNextMonth) || (nth > 0 && (nextWall.Day()-1)/7+1 == nth) {
+			return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", wall.Month(), nth, icalWeekdays[wall.Weekday()]), true
+		}
+	}
+
+	return "", false
+}
+
+func icalOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+func icalEscape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(v)
+}
+
+func icalUnescape(v string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(v)
+}
+
+// ICalendarParseOptions configures how ParseICalendar reads an iCalendar.
+type ICalendarParseOptions struct {
+	// Location is the time zone of the events with floating times, which aren't bound to any time
+	// zone. When nil, such events result in a schedule with an unknown time zone.
+	Location *time.Location
+
+	// OpenEndedAfter is the time from which recurrences are considered open-ended: events
+	// recurring until (UNTIL) this time or later are imported as if they recurred forever. When
+	// zero, events whose recurrence ends are not imported.
+	OpenEndedAfter time.Time
+}
+
+// ICalendarEventError reports an event that couldn't be expressed as opening hours.
+type ICalendarEventError struct {
+	UID     string
+	Summary string
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *ICalendarEventError) Error() string {
+	return fmt.Sprintf("event `%s`: %s", e.UID, e.Err)
+}
+
+// Unwrap returns the reason why the event couldn't be expressed as opening hours.
+func (e *ICalendarEventError) Unwrap() error {
+	return e.Err
+}
+
+// ParseICalendar derives weekly opening hours from the events of an iCalendar recurring every
+// week, in the time zone of the events. It does roughly the opposite of MarshalICalendar.
+//
+// Events that can't be expressed as opening hours, such as events recurring monthly or every
+// other week, events whose recurrence ends and events in another time zone than the first event,
+// are left out and reported in the second return value. Cancelled events and exceptions to the
+// recurrences (EXDATE, RECURRENCE-ID) are ignored. The error is only set when the iCalendar
+// itself is invalid.
+func ParseICalendar(data []byte, opts ICalendarParseOptions) (ZonedSchedule, []*ICalendarEventError, error) {
+	calendars, err := parseICalendar(data)
+	if err != nil {
+		return ZonedSchedule{}, nil, err
+	}
+
+	var (
+		schedule  ZonedSchedule
+		intervals []weekInterval
+		errs      []*ICalendarEventError
+		zoned     bool
+	)
+	for _, calendar := range calendars {
+		for _, event := range calendar.subcomponents("VEVENT") {
+			if status, _ := event.property("STATUS"); strings.EqualFold(status.value, "CANCELLED") {
+				continue
+			}
+			if _, ok := event.property("RECURRENCE-ID"); ok {
+				continue
+			}
+
+			wis, loc, err := event.weeklyIntervals(opts.OpenEndedAfter)
+			if loc == nil {
+				loc = opts.Location
+			}
+			if err == nil && zoned && locationName(loc) != locationName(schedule.Location) {
+				err = fmt.Errorf("in time zone `%s`: expected `%s`", locationName(loc), locationName(schedule.Location))
+			}
+			if err != nil {
+				uid, _ := event.property("UID")
+				summary, _ := event.property("SUMMARY")
+				errs = append(errs, &ICalendarEventError{UID: uid.value, Summary: icalUnescape(summary.value), Err: err})
+				continue
+			}
+
+			schedule.Location, zoned = loc, true
+			intervals = append(intervals, wis...)
+		}
+	}
+	schedule.Hours = openingHoursFromIntervals(intervals)
+
+	return schedule, errs, nil
+}
+
+// icalComponent is a component of an iCalendar, such as VCALENDAR or VEVENT.
+type icalComponent struct {
+	name       string
+	properties []icalProperty
+	components []*icalComponent
+}
+
+// icalProperty is a content line of an iCalendar, eg. "DTSTART;TZID=Europe/Amsterdam:20261019T080000".
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// property returns the first property of the component with the given name.
+func (c *icalComponent) property(name string) (icalProperty, bool) {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p, true
+		}
+	}
+
+	return icalProperty{}, false
+}
+
+// subcomponents returns the sub-components of the component with the given name.
+func (c *icalComponent) subcomponents(name string) []*icalComponent {
+	var components []*icalComponent
+	for _, sub := range c.components {
+		if sub.name == name {
+			components = append(components, sub)
+		}
+	}
+
+	return components
+}
+
+// parseICalendar parses iCalendar data into its top-level components, usually a single VCALENDAR.
+// Lines may be separated by CRLF or LF.
+func parseICalendar(data []byte) ([]*icalComponent, error) {
+	var lines []string
+	for _, l := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if n := len(lines); n > 0 && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+			lines[n-1] += l[1:]
+			continue
+		}
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	root := &icalComponent{}
+	stack := []*icalComponent{root}
+	for _, l := range lines {
+		p, err := parseICalProperty(l)
+		if err != nil {
+			return nil, err
+		}
+
+		current := stack[len(stack)-1]
+		switch p.name {
+		case "BEGIN":
+			c := &icalComponent{name: strings.ToUpper(p.value)}
+			current.components = append(current.components, c)
+			stack = append(stack, c)
+		case "END":
+			if current == root || current.name != strings.ToUpper(p.value) {
+				return nil, fmt.Errorf("invalid iCalendar: unexpected `%s`", l)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			current.properties = append(current.properties, p)
+		}
+	}
+	if current := stack[len(stack)-1]; current != root {
+		return nil, fmt.Errorf("invalid iCalendar: missing `END:%s`", current.name)
+	}
+
+	return root.components, nil
+}
+
+func parseICalProperty(line string) (icalProperty, error) {
+	invalid := fmt.Errorf("invalid iCalendar: invalid content line `%s`", line)
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return icalProperty{}, invalid
+	}
+
+	p := icalProperty{name: strings.ToUpper(line[:i])}
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		name, value, ok := strings.Cut(rest[1:], "=")
+		if !ok || name == "" {
+			return icalProperty{}, invalid
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.IndexByte(value[1:], '"')
+			if end < 0 {
+				return icalProperty{}, invalid
+			}
+			value, rest = value[1:end+1], value[end+2:]
+		} else {
+			end := strings.IndexAny(value, ";:")
+			if end < 0 {
+				return icalProperty{}, invalid
+			}
+			value, rest = value[:end], value[end:]
+		}
+
+		if p.params == nil {
+			p.params = make(map[string]string)
+		}
+		p.params[strings.ToUpper(name)] = value
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return icalProperty{}, invalid
+	}
+	p.value = rest[1:]
+
+	return p, nil
+}
+
+// dateTime returns the wall clock time of a DATE-TIME or DATE property along with its time zone.
+// The location is nil for floating times, which aren't bound to any time zone.
+func (p icalProperty) dateTime() (time.Time, *time.Location, error) {
+	var loc *time.Location
+	if tzid, ok := p.params["TZID"]; ok {
+		var err error
+		loc, err = time.LoadLocation(tzid)
+		if err != nil || tzid == "" {
+			return time.Time{}, nil, fmt.Errorf("invalid time zone `%s`", tzid)
+		}
+	}
+
+	value, layout := p.value, icalDateTimeFormat
+	if v, ok := strings.CutSuffix(value, "Z"); ok {
+		value, loc = v, time.UTC
+	}
+	if len(value) == len("20060102") {
+		layout = "20060102"
+	}
+
+	wall, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid %s `%s`", p.name, p.value)
+	}
+
+	return wall, loc, nil
+}
+
+var icalDurationRegexp = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalDuration parses a positive duration, eg. "PT8H30M" or "P1D". Days are taken to last 24
+// hours.
+func parseICalDuration(v string) (time.Duration, error) {
+	matches := icalDurationRegexp.FindStringSubmatch(strings.TrimPrefix(v, "+"))
+	if matches == nil || v == "P" || strings.HasSuffix(v, "T") {
+		return 0, fmt.Errorf("invalid duration `%s`", v)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{week, day, time.Hour, time.Minute, time.Second} {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration `%s`", v)
+		}
+		d += time.Duration(n) * unit
+	}
+
+	return d, nil
+}
+
+// span returns the wall clock time at which the component starts, its time zone and its duration,
+// given by either DTEND or DURATION.
+func (c *icalComponent) span() (time.Time, *time.Location, time.Duration, error) {
+	p, ok := c.property("DTSTART")
+	if !ok {
+		return time.Time{}, nil, 0, fmt.Errorf("missing DTSTART")
+	}
+	start, loc, err := p.dateTime()
+	if err != nil {
+		return time.Time{}, nil, 0, err
+	}
+
+	// Components starting on a date without time last a day by default.
+	var d time.Duration
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(p.value) == len("20060102") {
+		d = day
+	}
+
+	if p, ok := c.property("DTEND"); ok {
+		end, endLoc, err := p.dateTime()
+		if err != nil {
+			return time.Time{}, nil, 0, err
+		}
+		if loc != nil && endLoc != nil && endLoc != loc {
+			end = wallClock(inLocation(end, endLoc), loc)
+		}
+		d = end.Sub(start)
+	} else if p, ok := c.property("DURATION"); ok {
+		if d, err = parseICalDuration(p.value); err != nil {
+			return time.Time{}, nil, 0, err
+		}
+	}
+
+	return start, loc, d, nil
+}
+
+// weeklyIntervals returns the intervals in the week covered by the component when it recurs
+// according to its recurrence rule. Only rules recurring every week without end can be expressed
+// as opening hours, other rules are rejected. Rules ending (UNTIL) at or after openEndedAfter are
+// considered not to end, while a zero openEndedAfter rejects all rules with an end. Exceptions to
+// the rule, such as EXDATE, are ignored.
+func (c *icalComponent) weeklyIntervals(openEndedAfter time.Time) ([]weekInterval, *time.Location, error) {
+	start, loc, d, err := c.span()
+	if err != nil {
+		return nil, nil, err
+	}
+	if d <= 0 || d > week {
+		return nil, nil, fmt.Errorf("invalid duration `%s`: expected to be positive and at most a week", d)
+	}
+
+	p, ok := c.property("RRULE")
+	if !ok {
+		return nil, nil, fmt.Errorf("missing RRULE: expected to recur weekly")
+	}
+	rule := p.value
+
+	weekdays := []time.Weekday{start.Weekday()}
+	var weekly bool
+	for _, part := range strings.Split(rule, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			if !strings.EqualFold(value, "WEEKLY") {
+				return nil, nil, fmt.Errorf("unsupported RRULE `%s`: expected to recur weekly", rule)
+			}
+			weekly = true
+		case "INTERVAL":
+			if value != "1" {
+				return nil, nil, fmt.Errorf("unsupported RRULE `%s`: expected to recur every week", rule)
+			}
+		case "UNTIL":
+			until, untilLoc, err := icalProperty{name: "UNTIL", value: value}.dateTime()
+			if err != nil {
+				return nil, nil, err
+			}
+			if untilLoc == nil {
+				untilLoc = loc
+			}
+			if untilLoc == nil {
+				untilLoc = time.UTC
+			}
+			if openEndedAfter.IsZero() || inLocation(until, untilLoc).Before(openEndedAfter) {
+				return nil, nil, fmt.Errorf("unsupported RRULE `%s`: expected to recur without end", rule)
+			}
+		case "COUNT":
+			return nil, nil, fmt.Errorf("unsupported RRULE `%s`: expected to recur without end", rule)
+		case "BYDAY":
+			weekdays = weekdays[:0]
+			for _, code := range strings.Split(value, ",") {
+				i := slices.Index(icalWeekdays[:], strings.ToUpper(code))
+				if i < 0 {
+					return nil, nil, fmt.Errorf("unsupported RRULE `%s`: invalid weekday `%s`", rule, code)
+				}
+				weekdays = append(weekdays, time.Weekday(i))
+			}
+		case "WKST":
+		default:
+			return nil, nil, fmt.Errorf("unsupported RRULE `%s`: unexpected %s", rule, name)
+		}
+	}
+	if !weekly {
+		return nil, nil, fmt.Errorf("unsupported RRULE `%s`: expected to recur weekly", rule)
+	}
+
+	timeOfDay := start.Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC))
+
+	intervals := make([]weekInterval, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		offset := time.Duration((int(weekday)+6)%7)*day + timeOfDay
+		intervals = append(intervals, weekInterval{start: offset, end: offset + d})
+	}
+
+	return intervals, loc, nil
+}
@@ -0,0 +1,364 @@
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalICalendar(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timestamp := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		openingHours   string
+		opts           ICalendarOptions
+		expectedResult []string
+		expectedError  error
+	}{
+		"when in a time zone with daylight saving time": {
+			openingHours: "W1T08:00:00/W1T16:00:00,W5T22:00:00/W6T04:00:00",
+			opts: ICalendarOptions{
+				Location:  mustLoadLocation("Europe/Amsterdam"),
+				Start:     start,
+				Summary:   "Station 42",
+				UIDDomain: "station-42.example.com",
+				Timestamp: timestamp,
+				Closures:  []time.Time{time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VTIMEZONE",
+				"TZID:Europe/Amsterdam",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:20250330T020000",
+				"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+				"TZOFFSETFROM:+0100",
+				"TZOFFSETTO:+0200",
+				"TZNAME:CEST",
+				"END:DAYLIGHT",
+				"BEGIN:STANDARD",
+				"DTSTART:20251026T030000",
+				"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+				"TZOFFSETFROM:+0200",
+				"TZOFFSETTO:+0100",
+				"TZNAME:CET",
+				"END:STANDARD",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"UID:W1T080000-W1T160000@station-42.example.com",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261019T080000",
+				"DTEND;TZID=Europe/Amsterdam:20261019T160000",
+				"RRULE:FREQ=WEEKLY;BYDAY=MO",
+				"EXDATE;TZID=Europe/Amsterdam:20261228T080000",
+				"SUMMARY:Station 42",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:W5T220000-W6T040000@station-42.example.com",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Europe/Amsterdam:20261023T220000",
+				"DTEND;TZID=Europe/Amsterdam:20261024T040000",
+				"RRULE:FREQ=WEEKLY;BYDAY=FR",
+				"EXDATE;TZID=Europe/Amsterdam:20261225T220000",
+				"SUMMARY:Station 42",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		"when in a time zone without daylight saving time": {
+			openingHours: "W7T22:00:00/W1T06:00:00",
+			opts: ICalendarOptions{
+				Location:  mustLoadLocation("Asia/Kolkata"),
+				Start:     start,
+				Timestamp: timestamp,
+			},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VTIMEZONE",
+				"TZID:Asia/Kolkata",
+				"BEGIN:STANDARD",
+				"DTSTART:19700101T000000",
+				"TZOFFSETFROM:+0530",
+				"TZOFFSETTO:+0530",
+				"TZNAME:IST",
+				"END:STANDARD",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"UID:W7T220000-W1T060000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART;TZID=Asia/Kolkata:20261025T220000",
+				"DTEND;TZID=Asia/Kolkata:20261026T060000",
+				"RRULE:FREQ=WEEKLY;BYDAY=SU",
+				"SUMMARY:Open",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		"when in UTC": {
+			openingHours: "W3T08:00:00/W3T16:00:00",
+			opts: ICalendarOptions{
+				Location:  time.UTC,
+				Start:     start,
+				ProductID: "-//Example//Sites//EN",
+				Timestamp: timestamp,
+				Closures:  []time.Time{time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 6, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Example//Sites//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VEVENT",
+				"UID:W3T080000-W3T160000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART:20261021T080000Z",
+				"DTEND:20261021T160000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=WE",
+				"EXDATE:20261230T080000Z,20270106T080000Z",
+				"SUMMARY:Open",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		"when summary needs escaping and folding": {
+			openingHours: "W3T08:00:00/W3T16:00:00",
+			opts: ICalendarOptions{
+				Location:  time.UTC,
+				Start:     start,
+				Summary:   "Laadstation Café, Amsterdam; open voor iedereen met een laadpas of creditcard",
+				Timestamp: timestamp,
+			},
+			expectedResult: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//ParenInc//openinghours//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VEVENT",
+				"UID:W3T080000-W3T160000@openinghours",
+				"DTSTAMP:20261018T120000Z",
+				"DTSTART:20261021T080000Z",
+				"DTEND:20261021T160000Z",
+				"RRULE:FREQ=WEEKLY;BYDAY=WE",
+				"SUMMARY:Laadstation Café\\, Amsterdam\\; open voor iedereen met een laadpas ",
+				" of creditcard",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			},
+		},
+		"when time zone missing": {
+			openingHours:  "W3T08:00:00/W3T16:00:00",
+			opts:          ICalendarOptions{Start: start},
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, err := MarshalICalendar(ohs, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedResult == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, strings.Join(tt.expectedResult, "\r\n")+"\r\n", string(result))
+			}
+		})
+	}
+}
+
+func TestParseICalendar(t *testing.T) {
+	calendar := func(events ...[]string) []byte {
+		lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Example//EN"}
+		for _, event := range events {
+			lines = append(append(append(lines, "BEGIN:VEVENT"), event...), "END:VEVENT")
+		}
+		return []byte(strings.Join(append(lines, "END:VCALENDAR"), "\r\n") + "\r\n")
+	}
+
+	tests := map[string]struct {
+		data             []byte
+		opts             ICalendarParseOptions
+		expectedSchedule string
+		expectedErrors   []string
+		expectedError    error
+	}{
+		"when weekly recurring events": {
+			data: calendar(
+				[]string{
+					"UID:weekdays",
+					"DTSTART;TZID=Europe/Amsterdam:20261019T080000",
+					"DTEND;TZID=Europe/Amsterdam:20261019T160000",
+					"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+					"EXDATE;TZID=Europe/Amsterdam:20261225T080000",
+				},
+				[]string{
+					"UID:friday-night",
+					"DTSTART;TZID=Europe/Amsterdam:20261023T220000",
+					"DURATION:PT6H",
+					"RRULE:FREQ=WEEKLY;INTERVAL=1",
+				},
+				[]string{
+					"UID:christmas",
+					"RECURRENCE-ID;TZID=Europe/Amsterdam:20261225T080000",
+					"DTSTART;TZID=Europe/Amsterdam:20261225T100000",
+					"DTEND;TZID=Europe/Amsterdam:20261225T120000",
+				},
+				[]string{
+					"UID:sunday",
+					"STATUS:CANCELLED",
+					"DTSTART;TZID=Europe/Amsterdam:20261025T100000",
+					"DTEND;TZID=Europe/Amsterdam:20261025T120000",
+					"RRULE:FREQ=WEEKLY",
+				},
+			),
+			expectedSchedule: "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00,W3T08:00:00/W3T16:00:00,W4T08:00:00/W4T16:00:00,W5T08:00:00/W5T16:00:00,W5T22:00:00/W6T04:00:00;tz=Europe/Amsterdam",
+		},
+		"when events can't be represented": {
+			data: calendar(
+				[]string{
+					"UID:weekdays",
+					"DTSTART:20261019T080000Z",
+					"DTEND:20261019T160000Z",
+					"RRULE:FREQ=WEEKLY;BYDAY=MO,TU",
+				},
+				[]string{
+					"UID:monthly",
+					"SUMMARY:Market\\, first saturday",
+					"DTSTART:20261107T080000Z",
+					"DTEND:20261107T120000Z",
+					"RRULE:FREQ=MONTHLY;BYDAY=1SA",
+				},
+				[]string{
+					"UID:every-other-week",
+					"DTSTART:20261021T080000Z",
+					"DTEND:20261021T120000Z",
+					"RRULE:FREQ=WEEKLY;INTERVAL=2",
+				},
+				[]string{
+					"UID:ten-times",
+					"DTSTART:20261022T080000Z",
+					"DTEND:20261022T120000Z",
+					"RRULE:FREQ=WEEKLY;COUNT=10",
+				},
+				[]string{
+					"UID:until",
+					"DTSTART:20261023T080000Z",
+					"DTEND:20261023T120000Z",
+					"RRULE:FREQ=WEEKLY;UNTIL=20261231T235959Z",
+				},
+				[]string{
+					"UID:once",
+					"DTSTART:20261024T080000Z",
+					"DTEND:20261024T120000Z",
+				},
+				[]string{
+					"UID:elsewhere",
+					"DTSTART;TZID=Europe/London:20261025T080000",
+					"DTEND;TZID=Europe/London:20261025T120000",
+					"RRULE:FREQ=WEEKLY",
+				},
+			),
+			expectedSchedule: "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00;tz=UTC",
+			expectedErrors: []string{
+				"event `monthly`: unsupported RRULE `FREQ=MONTHLY;BYDAY=1SA`: expected to recur weekly",
+				"event `every-other-week`: unsupported RRULE `FREQ=WEEKLY;INTERVAL=2`: expected to recur every week",
+				"event `ten-times`: unsupported RRULE `FREQ=WEEKLY;COUNT=10`: expected to recur without end",
+				"event `until`: unsupported RRULE `FREQ=WEEKLY;UNTIL=20261231T235959Z`: expected to recur without end",
+				"event `once`: missing RRULE: expected to recur weekly",
+				"event `elsewhere`: in time zone `Europe/London`: expected `UTC`",
+			},
+		},
+		"when recurrence ends after it is considered open-ended": {
+			data: calendar([]string{
+				"UID:until",
+				"DTSTART;TZID=America/New_York:20261023T080000",
+				"DTEND;TZID=America/New_York:20261023T120000",
+				"RRULE:FREQ=WEEKLY;UNTIL=20991231T235959Z",
+			}),
+			opts:             ICalendarParseOptions{OpenEndedAfter: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectedSchedule: "W5T08:00:00/W5T12:00:00;tz=America/New_York",
+		},
+		"when recurrence ends before it is considered open-ended": {
+			data: calendar([]string{
+				"UID:until",
+				"DTSTART;TZID=America/New_York:20261023T080000",
+				"DTEND;TZID=America/New_York:20261023T120000",
+				"RRULE:FREQ=WEEKLY;UNTIL=20271231T235959Z",
+			}),
+			opts:           ICalendarParseOptions{OpenEndedAfter: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectedErrors: []string{"event `until`: unsupported RRULE `FREQ=WEEKLY;UNTIL=20271231T235959Z`: expected to recur without end"},
+		},
+		"when floating times": {
+			data: calendar([]string{
+				"UID:floating",
+				"DTSTART:20261025T220000",
+				"DTEND:20261026T060000",
+				"RRULE:FREQ=WEEKLY",
+			}),
+			opts:             ICalendarParseOptions{Location: mustLoadLocation("Asia/Kolkata")},
+			expectedSchedule: "W7T22:00:00/W1T06:00:00;tz=Asia/Kolkata",
+		},
+		"when floating times without location": {
+			data: calendar([]string{
+				"UID:floating",
+				"DTSTART:20261025T220000",
+				"DTEND:20261026T060000",
+				"RRULE:FREQ=WEEKLY",
+			}),
+			expectedSchedule: "W7T22:00:00/W1T06:00:00",
+		},
+		"when iCalendar invalid": {
+			data:          []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n"),
+			expectedError: fmt.Errorf("invalid iCalendar: unexpected `END:VCALENDAR`"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, errs, err := ParseICalendar(tt.data, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedSchedule, result.String())
+
+			var actualErrors []string
+			for _, e := range errs {
+				actualErrors = append(actualErrors, e.Error())
+			}
+			assert.Equal(t, tt.expectedErrors, actualErrors)
+		})
+	}
+}
+
+func TestICalendarRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ohs := mustParseOpeningHours("W1T08:00:00/W1T16:00:00,W3T08:30:00/W3T12:00:00,W5T22:00:00/W6T04:00:00,W7T20:00:00/W1T02:00:00")
+	loc := mustLoadLocation("Australia/Sydney")
+
+	data, err := MarshalICalendar(ohs, ICalendarOptions{Location: loc, Start: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+
+	result, errs, err := ParseICalendar(data, ICalendarParseOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Equal(t, OpeningHoursSliceToString(ohs)+";tz=Australia/Sydney", result.String())
+}
@@ -0,0 +1,81 @@
+package openinghours
+
+import (
+	"iter"
+	"time"
+)
+
+// Interval is a half-open period of time [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the interval.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Occurrences returns the periods during which the opening hours are open between from and to,
+// with the opening hours being interpreted in the given location.
+//
+// The periods are returned in chronological order and are clipped to [from, to). Ranges that
+// follow each other without interruption, such as an overnight range and the range of the next
+// morning, are merged into a single period.
+//
+// The opening hours follow the wall clock of the location, so that a range from 08:00 to 16:00 is
+// one hour shorter or longer on days when the clocks change. Opening and closing times that are
+// skipped when the clocks go forward take effect at the transition, and opening and closing times
+// that occur twice when the clocks go back take effect at their first occurrence. For example, on
+// the day the clocks go back from 02:00 to 01:00, a range from 01:00 to 03:00 lasts three hours.
+//
+// Invalid opening hours are ignored. The location must not be nil.
+func Occurrences(ohs []OpeningHours, from, to time.Time, loc *time.Location) iter.Seq[Interval] {
+	intervals := weekIntervals(ohs)
+
+	return func(yield func(Interval) bool) {
+		if len(intervals) == 0 || !from.Before(to) {
+			return
+		}
+
+		clip := func(i Interval) Interval {
+			if i.Start.Before(from) {
+				i.Start = from
+			}
+			if i.End.After(to) {
+				i.End = to
+			}
+			return i
+		}
+
+		var pending *Interval
+		for monday := startOfWeek(from, loc); inLocation(monday, loc).Before(to); monday = monday.AddDate(0, 0, 7) {
+			for _, wi := range intervals {
+				start := inLocation(monday.Add(wi.start), loc)
+				end := inLocation(monday.Add(wi.end), loc)
+				if !end.After(start) || !end.After(from) {
+					continue
+				}
+				if !start.Before(to) {
+					break
+				}
+
+				if pending != nil && !start.After(pending.End) {
+					if end.After(pending.End) {
+						pending.End = end
+					}
+					continue
+				}
+
+				if pending != nil && !yield(clip(*pending)) {
+					return
+				}
+				pending = &Interval{Start: start, End: end}
+			}
+		}
+
+		if pending != nil {
+			yield(clip(*pending))
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package openinghours
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccurrences(t *testing.T) {
+	utc := func(v string) time.Time {
+		t, err := time.Parse(time.DateTime, v)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	tests := map[string]struct {
+		openingHours   string
+		from           time.Time
+		to             time.Time
+		loc            *time.Location
+		expectedResult []Interval
+	}{
+		"when single week": {
+			openingHours: "W1T08:00:00/W1T16:00:00,W3T10:00:00/W3T12:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 08:00:00"), End: utc("2026-10-19 16:00:00")},
+				{Start: utc("2026-10-21 10:00:00"), End: utc("2026-10-21 12:00:00")},
+			},
+		},
+		"when window starts mid-week": {
+			openingHours: "W1T08:00:00/W1T16:00:00,W3T10:00:00/W3T12:00:00",
+			from:         utc("2026-10-20 00:00:00"),
+			to:           utc("2026-10-27 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-21 10:00:00"), End: utc("2026-10-21 12:00:00")},
+				{Start: utc("2026-10-26 08:00:00"), End: utc("2026-10-26 16:00:00")},
+			},
+		},
+		"when window cuts through a range": {
+			openingHours: "W1T08:00:00/W1T16:00:00",
+			from:         utc("2026-10-19 10:00:00"),
+			to:           utc("2026-10-26 09:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 10:00:00"), End: utc("2026-10-19 16:00:00")},
+				{Start: utc("2026-10-26 08:00:00"), End: utc("2026-10-26 09:00:00")},
+			},
+		},
+		"when ranges meet at midnight": {
+			openingHours: "W1T18:00:00/W1T24:00:00,W2T00:00:00/W2T02:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 18:00:00"), End: utc("2026-10-20 02:00:00")},
+			},
+		},
+		"when range wraps around the end of the week": {
+			openingHours: "W7T22:00:00/W1T06:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-11-02 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 00:00:00"), End: utc("2026-10-19 06:00:00")},
+				{Start: utc("2026-10-25 22:00:00"), End: utc("2026-10-26 06:00:00")},
+				{Start: utc("2026-11-01 22:00:00"), End: utc("2026-11-02 00:00:00")},
+			},
+		},
+		"when 24/7": {
+			openingHours: TwentyFourSevenString,
+			from:         utc("2026-10-19 12:00:00"),
+			to:           utc("2026-11-02 12:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 12:00:00"), End: utc("2026-11-02 12:00:00")},
+			},
+		},
+		"when in another time zone": {
+			openingHours: "W1T08:00:00/W1T16:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.FixedZone("UTC+2", 2*60*60),
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 06:00:00"), End: utc("2026-10-19 14:00:00")},
+			},
+		},
+		"when seconds specified": {
+			openingHours: "W5T08:00:30/W5T23:59:59",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-23 08:00:30"), End: utc("2026-10-23 23:59:59")},
+			},
+		},
+		"when overlapping ranges": {
+			openingHours: "W1T08:00:00/W1T12:00:00,W1T10:00:00/W1T16:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 08:00:00"), End: utc("2026-10-19 16:00:00")},
+			},
+		},
+		"when opening time not specified": {
+			openingHours: "/W1T16:00:00",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-19 00:00:00"), End: utc("2026-10-19 16:00:00")},
+			},
+		},
+		"when closing time not specified": {
+			openingHours: "W7T08:00:00/",
+			from:         utc("2026-10-19 00:00:00"),
+			to:           utc("2026-10-26 00:00:00"),
+			loc:          time.UTC,
+			expectedResult: []Interval{
+				{Start: utc("2026-10-25 08:00:00"), End: utc("2026-10-26 00:00:00")},
+			},
+		},
+		"when opening and closing times not specified": {
+			openingHours:   "/",
+			from:           utc("2026-10-19 00:00:00"),
+			to:             utc("2026-10-26 00:00:00"),
+			loc:            time.UTC,
+			expectedResult: nil,
+		},
+		"when window empty": {
+			openingHours:   TwentyFourSevenString,
+			from:           utc("2026-10-19 00:00:00"),
+			to:             utc("2026-10-19 00:00:00"),
+			loc:            time.UTC,
+			expectedResult: nil,
+		},
+		"when opening hours are empty": {
+			openingHours:   "",
+			from:           utc("2026-10-19 00:00:00"),
+			to:             utc("2026-10-26 00:00:00"),
+			loc:            time.UTC,
+			expectedResult: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result := slices.Collect(Occurrences(ohs, tt.from, tt.to, tt.loc))
+			assert.Len(t, result, len(tt.expectedResult))
+			for i := range min(len(result), len(tt.expectedResult)) {
+				assert.True(t, tt.expectedResult[i].Start.Equal(result[i].Start), "start %d: %s", i, result[i].Start)
+				assert.True(t, tt.expectedResult[i].End.Equal(result[i].End), "end %d: %s", i, result[i].End)
+			}
+		})
+	}
+}
+
+func TestOccurrencesStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	ohs := []OpeningHours{{
+		Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 0},
+		Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 60},
+	}}
+
+	var count int
+	for range Occurrences(ohs, from, to, time.UTC) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
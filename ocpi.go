@@ -0,0 +1,333 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OCPIVersion is a version of the OCPI spec.
+type OCPIVersion string
+
+const (
+	OCPIVersion211 OCPIVersion = "2.1.1"
+	OCPIVersion221 OCPIVersion = "2.2.1"
+	OCPIVersion30  OCPIVersion = "3.0"
+)
+
+// ocpiEndOfDay is the period_end used in OCPI 2.1.1 and 2.2.1 for ranges running until midnight
+// at the end of the day, as period_end must be later than period_begin and 24:00 isn't a valid
+// time in these versions.
+const ocpiEndOfDay = "23:59"
+
+// OCPIOptions configures the regular hours produced by the OCPI conversions.
+type OCPIOptions struct {
+	// Normalize sorts the regular hours by weekday and period_begin and merges the periods of a
+	// day that overlap or follow each other, so that each day has as few rows as possible. Full
+	// days are always given as 00:00-00:00 (00:00-23:59 in OCPI 2.1.1 and 2.2.1), whether they
+	// come from a single range or from the middle of a range spanning several days. The result is
+	// checked to be sorted and without overlaps before being returned, on top of the rules of the
+	// OCPI version that are always checked.
+	Normalize bool
+}
+
+// DefaultOCPIOptions are used by GetOCPIOpeningTimes, GetOCPI211Hours, GetOCPI221Hours and
+// MarshalOCPIHours: the regular hours follow the order of the opening hours.
+var DefaultOCPIOptions = OCPIOptions{}
+
+// GetOCPIOpeningTimesWithOptions is like GetOCPIOpeningTimes, with the given options.
+func GetOCPIOpeningTimesWithOptions(ohs []OpeningHours, opts OCPIOptions) (OCPIOpeningTimes, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return OCPIOpeningTimes{}, err
+		}
+	}
+
+	if isTwentyFourSeven(ohs) {
+		return OCPIOpeningTimes{TwentyFourSeven: true}, nil
+	}
+
+	// When normalizing, ranges together covering the whole week are 24/7 as well.
+	if wis := weekIntervals(ohs); opts.Normalize && len(wis) == 1 && wis[0] == (weekInterval{start: 0, end: week}) {
+		return OCPIOpeningTimes{TwentyFourSeven: true}, nil
+	}
+
+	var regularHours []OCPIRegularHours
+	if opts.Normalize {
+		regularHours = normalizedOCPIRegularHours(ohs)
+		if err := validateOCPIRegularHours(regularHours, OCPIVersion30, true); err != nil {
+			return OCPIOpeningTimes{}, err
+		}
+	} else {
+		regularHours = ocpiRegularHours(ohs)
+	}
+	if len(regularHours) == 0 {
+		return OCPIOpeningTimes{}, nil
+	}
+
+	return OCPIOpeningTimes{
+		TwentyFourSeven: false,
+		RegularHours:    &regularHours,
+	}, nil
+}
+
+// normalizedOCPIRegularHours returns the regular hours of the opening hours as in OCPI 3.0, sorted
+// and merged per day, see OCPIOptions.
+func normalizedOCPIRegularHours(ohs []OpeningHours) []OCPIRegularHours {
+	var regularHours []OCPIRegularHours
+	for weekday, periods := range dailyPeriods(ohs) {
+		// Rounding to the minute may make periods of the same day overlap, so they are merged again.
+		var merged [][2]int
+		for _, p := range periods {
+			begin, end := p.minutes()
+			if n := len(merged); n > 0 && begin <= merged[n-1][1] {
+				merged[n-1][1] = max(merged[n-1][1], end)
+				continue
+			}
+			merged = append(merged, [2]int{begin, end})
+		}
+
+		for _, m := range merged {
+			regularHours = append(regularHours, OCPIRegularHours{
+				Weekday:     weekday + 1,
+				PeriodBegin: minutesSinceMidnightToTime(m[0]),
+				PeriodEnd:   minutesSinceMidnightToTime(m[1] % 1440), // 24:00 is represented as 00:00 in the OCPI spec
+			})
+		}
+	}
+
+	return regularHours
+}
+
+// validateOCPIRegularHours checks that the regular hours follow the rules of the given version,
+// and when sorted is set, that they are sorted and don't overlap.
+func validateOCPIRegularHours(regularHours []OCPIRegularHours, version OCPIVersion, sorted bool) error {
+	var previous weekInterval
+	for i, rh := range regularHours {
+		wi, err := rh.interval(version)
+		if err != nil {
+			return fmt.Errorf("invalid regular hours #%d: %s", i+1, err)
+		}
+		if sorted && i > 0 && wi.start < previous.end {
+			return fmt.Errorf("invalid regular hours #%d: expected to be after regular hours #%d", i+1, i)
+		}
+		previous = wi
+	}
+
+	return nil
+}
+
+// OCPI211Hours represents the Hours class from the OCPI 2.1.1 standard, in which the regular hours
+// and twentyfourseven are a choice: twentyfourseven is only given when true.
+type OCPI211Hours struct {
+	RegularHours    []OCPIRegularHours `json:"regular_hours,omitempty"`
+	TwentyFourSeven bool               `json:"twentyfourseven,omitempty" example:"false"`
+}
+
+// OCPI221Hours represents the Hours class from the OCPI 2.2.1 standard, in which twentyfourseven
+// is required and the regular hours must be given when it is false.
+type OCPI221Hours struct {
+	TwentyFourSeven bool               `json:"twentyfourseven" example:"false"`
+	RegularHours    []OCPIRegularHours `json:"regular_hours,omitempty"`
+}
+
+// GetOCPI211Hours is like GetOCPIOpeningTimes, for OCPI 2.1.1. As period_end must be later than
+// period_begin, ranges running until midnight end at 23:59. An error is returned when the opening
+// hours are never open, as this can't be expressed in OCPI 2.1.1, or when the regular hours don't
+// follow the rules of the version.
+func GetOCPI211Hours(ohs []OpeningHours) (OCPI211Hours, error) {
+	return GetOCPI211HoursWithOptions(ohs, DefaultOCPIOptions)
+}
+
+// GetOCPI211HoursWithOptions is like GetOCPI211Hours, with the given options.
+func GetOCPI211HoursWithOptions(ohs []OpeningHours, opts OCPIOptions) (OCPI211Hours, error) {
+	twentyFourSeven, regularHours, err := ocpi2RegularHours(ohs, OCPIVersion211, opts)
+	if err != nil {
+		return OCPI211Hours{}, err
+	}
+
+	return OCPI211Hours{RegularHours: regularHours, TwentyFourSeven: twentyFourSeven}, nil
+}
+
+// GetOCPI221Hours is like GetOCPI211Hours, for OCPI 2.2.1.
+func GetOCPI221Hours(ohs []OpeningHours) (OCPI221Hours, error) {
+	return GetOCPI221HoursWithOptions(ohs, DefaultOCPIOptions)
+}
+
+// GetOCPI221HoursWithOptions is like GetOCPI221Hours, with the given options.
+func GetOCPI221HoursWithOptions(ohs []OpeningHours, opts OCPIOptions) (OCPI221Hours, error) {
+	twentyFourSeven, regularHours, err := ocpi2RegularHours(ohs, OCPIVersion221, opts)
+	if err != nil {
+		return OCPI221Hours{}, err
+	}
+
+	return OCPI221Hours{TwentyFourSeven: twentyFourSeven, RegularHours: regularHours}, nil
+}
+
+// ocpi2RegularHours returns the regular hours of the opening hours for OCPI 2.1.1 and 2.2.1.
+func ocpi2RegularHours(ohs []OpeningHours, version OCPIVersion, opts OCPIOptions) (bool, []OCPIRegularHours, error) {
+	ocpiOpeningTimes, err := GetOCPIOpeningTimesWithOptions(ohs, opts)
+	if err != nil {
+		return false, nil, err
+	}
+	if ocpiOpeningTimes.TwentyFourSeven {
+		return true, nil, nil
+	}
+	if ocpiOpeningTimes.RegularHours == nil {
+		return false, nil, fmt.Errorf("opening hours are never open: can't be expressed in OCPI %s", version)
+	}
+
+	regularHours := *ocpiOpeningTimes.RegularHours
+	for i := range regularHours {
+		if regularHours[i].PeriodEnd == "00:00" {
+			regularHours[i].PeriodEnd = ocpiEndOfDay
+		}
+	}
+	if err := validateOCPIRegularHours(regularHours, version, opts.Normalize); err != nil {
+		return false, nil, err
+	}
+
+	return false, regularHours, nil
+}
+
+// ParseOCPIOpeningTimes does the opposite of GetOCPIOpeningTimes. The regular hours are validated
+// according to OCPI 3.0: period_end must be later than period_begin, or be 00:00 for midnight at
+// the end of the day.
+func ParseOCPIOpeningTimes(v OCPIOpeningTimes) ([]OpeningHours, error) {
+	if v.TwentyFourSeven {
+		return []OpeningHours{TwentyFourSevenOH}, nil
+	}
+	if v.RegularHours == nil {
+		return []OpeningHours{}, nil
+	}
+
+	return parseOCPIRegularHours(*v.RegularHours, OCPIVersion30)
+}
+
+// ParseOCPI211Hours does the opposite of GetOCPI211Hours: a period_end of 23:59 is read as
+// midnight at the end of the day.
+func ParseOCPI211Hours(v OCPI211Hours) ([]OpeningHours, error) {
+	if v.TwentyFourSeven {
+		return []OpeningHours{TwentyFourSevenOH}, nil
+	}
+	if len(v.RegularHours) == 0 {
+		return nil, fmt.Errorf("invalid hours: expected either regular hours or twentyfourseven")
+	}
+
+	return parseOCPIRegularHours(v.RegularHours, OCPIVersion211)
+}
+
+// ParseOCPI221Hours does the opposite of GetOCPI221Hours: a period_end of 23:59 is read as
+// midnight at the end of the day.
+func ParseOCPI221Hours(v OCPI221Hours) ([]OpeningHours, error) {
+	if v.TwentyFourSeven {
+		return []OpeningHours{TwentyFourSevenOH}, nil
+	}
+	if len(v.RegularHours) == 0 {
+		return nil, fmt.Errorf("invalid hours: expected regular hours when twentyfourseven is false")
+	}
+
+	return parseOCPIRegularHours(v.RegularHours, OCPIVersion221)
+}
+
+// parseOCPIRegularHours returns the opening hours of the regular hours, validated according to
+// the given version.
+func parseOCPIRegularHours(regularHours []OCPIRegularHours, version OCPIVersion) ([]OpeningHours, error) {
+	intervals := make([]weekInterval, 0, len(regularHours))
+	for i, rh := range regularHours {
+		wi, err := rh.interval(version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular hours #%d: %s", i+1, err)
+		}
+		intervals = append(intervals, wi)
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+func (rh OCPIRegularHours) interval(version OCPIVersion) (weekInterval, error) {
+	if rh.Weekday < 1 || rh.Weekday > 7 {
+		return weekInterval{}, fmt.Errorf("invalid weekday `%d`: expected to be between 1 (monday) and 7 (sunday)", rh.Weekday)
+	}
+
+	begin, err := parseClockTime(rh.PeriodBegin)
+	if err != nil {
+		return weekInterval{}, err
+	}
+	end, err := parseClockTime(rh.PeriodEnd)
+	if err != nil {
+		return weekInterval{}, err
+	}
+	if begin == 1440 || end == 1440 {
+		return weekInterval{}, fmt.Errorf("invalid time `24:00`: expected to be before 24:00 in OCPI %s", version)
+	}
+
+	switch {
+	case version == OCPIVersion30 && rh.PeriodEnd == "00:00":
+		end = 1440
+	case version != OCPIVersion30 && rh.PeriodEnd == ocpiEndOfDay:
+		end = 1440
+	}
+	if end <= begin {
+		return weekInterval{}, fmt.Errorf("invalid period `%s-%s`: expected period_end to be later than period_begin", rh.PeriodBegin, rh.PeriodEnd)
+	}
+
+	midnight := time.Duration(rh.Weekday-1) * day
+
+	return weekInterval{start: midnight + time.Duration(begin)*time.Minute, end: midnight + time.Duration(end)*time.Minute}, nil
+}
+
+// MarshalOCPIHours returns the OCPI Hours object of the opening hours as JSON, as specified by the
+// given version of the OCPI spec.
+func MarshalOCPIHours(ohs []OpeningHours, version OCPIVersion) ([]byte, error) {
+	return MarshalOCPIHoursWithOptions(ohs, version, DefaultOCPIOptions)
+}
+
+// MarshalOCPIHoursWithOptions is like MarshalOCPIHours, with the given options.
+func MarshalOCPIHoursWithOptions(ohs []OpeningHours, version OCPIVersion, opts OCPIOptions) ([]byte, error) {
+	var (
+		v   any
+		err error
+	)
+	switch version {
+	case OCPIVersion211:
+		v, err = GetOCPI211HoursWithOptions(ohs, opts)
+	case OCPIVersion221:
+		v, err = GetOCPI221HoursWithOptions(ohs, opts)
+	case OCPIVersion30:
+		v, err = GetOCPIOpeningTimesWithOptions(ohs, opts)
+	default:
+		return nil, fmt.Errorf("unsupported OCPI version `%s`", version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalOCPIHours does the opposite of MarshalOCPIHours.
+func UnmarshalOCPIHours(data []byte, version OCPIVersion) ([]OpeningHours, error) {
+	switch version {
+	case OCPIVersion211:
+		var v OCPI211Hours
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return ParseOCPI211Hours(v)
+	case OCPIVersion221:
+		var v OCPI221Hours
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return ParseOCPI221Hours(v)
+	case OCPIVersion30:
+		var v OCPIOpeningTimes
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return ParseOCPIOpeningTimes(v)
+	default:
+		return nil, fmt.Errorf("unsupported OCPI version `%s`", version)
+	}
+}
@@ -0,0 +1,347 @@
+package openinghours
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCPIHoursGolden(t *testing.T) {
+	tests := map[string]string{
+		"regular":         "W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00",
+		"overnight":       "W1T06:00:00/W1T12:00:00,W5T20:00:00/W7T02:00:00",
+		"twentyfourseven": TwentyFourSevenString,
+	}
+
+	for _, version := range []OCPIVersion{OCPIVersion211, OCPIVersion221, OCPIVersion30} {
+		for name, openingHours := range tests {
+			version, name, openingHours := version, name, openingHours
+
+			t.Run(string(version)+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				golden, err := os.ReadFile(filepath.Join("testdata", "ocpi", string(version), name+".json"))
+				assert.NoError(t, err)
+
+				result, err := MarshalOCPIHours(mustParseOpeningHours(openingHours), version)
+				assert.NoError(t, err)
+				assert.JSONEq(t, string(golden), string(result))
+
+				ohs, err := UnmarshalOCPIHours(golden, version)
+				assert.NoError(t, err)
+				assert.Equal(t, openingHours, OpeningHoursSliceToString(ohs))
+			})
+		}
+	}
+}
+
+func TestMarshalOCPIHours(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		version        OCPIVersion
+		expectedResult string
+		expectedError  error
+	}{
+		"when never open in 2.1.1": {
+			openingHours:  []OpeningHours{},
+			version:       OCPIVersion211,
+			expectedError: fmt.Errorf("opening hours are never open: can't be expressed in OCPI 2.1.1"),
+		},
+		"when never open in 2.2.1": {
+			openingHours:  []OpeningHours{},
+			version:       OCPIVersion221,
+			expectedError: fmt.Errorf("opening hours are never open: can't be expressed in OCPI 2.2.1"),
+		},
+		"when never open in 3.0": {
+			openingHours:   []OpeningHours{},
+			version:        OCPIVersion30,
+			expectedResult: `{"twentyfourseven": false}`,
+		},
+		"when closing at midnight in 2.2.1": {
+			openingHours:   mustParseOpeningHours("W3T18:00:00/W4T00:00:00"),
+			version:        OCPIVersion221,
+			expectedResult: `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "23:59"}]}`,
+		},
+		"when closing before opening on the same day in 2.1.1": {
+			openingHours: mustParseOpeningHours("W3T20:00:00/W3T04:00:00"),
+			version:      OCPIVersion211,
+			expectedResult: `{"regular_hours": [
+				{"weekday": 3, "period_begin": "20:00", "period_end": "23:59"},
+				{"weekday": 4, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 5, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 6, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 7, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 1, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 2, "period_begin": "00:00", "period_end": "23:59"},
+				{"weekday": 3, "period_begin": "00:00", "period_end": "04:00"}
+			]}`,
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1500},
+				Close: &TimeInWeek{Weekday: 2, MinutesSinceMidnight: 0},
+			}},
+			version:       OCPIVersion211,
+			expectedError: fmt.Errorf("invalid opening hours: invalid time `1500`: expected to be between 0 and 1440 minutes since midnight"),
+		},
+		"when version unsupported": {
+			openingHours:  []OpeningHours{TwentyFourSevenOH},
+			version:       "2.0",
+			expectedError: fmt.Errorf("unsupported OCPI version `2.0`"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := MarshalOCPIHours(tt.openingHours, tt.version)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.JSONEq(t, tt.expectedResult, string(result))
+			}
+		})
+	}
+}
+
+func TestUnmarshalOCPIHours(t *testing.T) {
+	tests := map[string]struct {
+		data           string
+		version        OCPIVersion
+		expectedResult string
+		expectedError  error
+	}{
+		"when midnight as 00:00 in 3.0": {
+			data:           `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "00:00"}]}`,
+			version:        OCPIVersion30,
+			expectedResult: "W3T18:00:00/W3T24:00:00",
+		},
+		"when midnight as 00:00 in 2.2.1": {
+			data:          `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "00:00"}]}`,
+			version:       OCPIVersion221,
+			expectedError: fmt.Errorf("invalid regular hours #1: invalid period `18:00-00:00`: expected period_end to be later than period_begin"),
+		},
+		"when midnight as 23:59 in 2.1.1": {
+			data:           `{"regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "23:59"}, {"weekday": 4, "period_begin": "00:00", "period_end": "02:00"}]}`,
+			version:        OCPIVersion211,
+			expectedResult: "W3T18:00:00/W4T02:00:00",
+		},
+		"when 23:59 in 3.0": {
+			data:           `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "23:59"}]}`,
+			version:        OCPIVersion30,
+			expectedResult: "W3T18:00:00/W3T23:59:00",
+		},
+		"when overnight period": {
+			data:          `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "22:00", "period_end": "06:00"}]}`,
+			version:       OCPIVersion30,
+			expectedError: fmt.Errorf("invalid regular hours #1: invalid period `22:00-06:00`: expected period_end to be later than period_begin"),
+		},
+		"when 24:00": {
+			data:          `{"twentyfourseven": false, "regular_hours": [{"weekday": 3, "period_begin": "18:00", "period_end": "24:00"}]}`,
+			version:       OCPIVersion30,
+			expectedError: fmt.Errorf("invalid regular hours #1: invalid time `24:00`: expected to be before 24:00 in OCPI 3.0"),
+		},
+		"when weekday invalid": {
+			data:          `{"twentyfourseven": false, "regular_hours": [{"weekday": 1, "period_begin": "08:00", "period_end": "18:00"}, {"weekday": 0, "period_begin": "08:00", "period_end": "18:00"}]}`,
+			version:       OCPIVersion221,
+			expectedError: fmt.Errorf("invalid regular hours #2: invalid weekday `0`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+		"when time invalid": {
+			data:          `{"regular_hours": [{"weekday": 1, "period_begin": "8:00", "period_end": "18:00"}]}`,
+			version:       OCPIVersion211,
+			expectedError: fmt.Errorf("invalid regular hours #1: invalid time `8:00`: expected to be formatted as hh:mm"),
+		},
+		"when neither regular hours nor twentyfourseven in 2.1.1": {
+			data:          `{}`,
+			version:       OCPIVersion211,
+			expectedError: fmt.Errorf("invalid hours: expected either regular hours or twentyfourseven"),
+		},
+		"when no regular hours in 2.2.1": {
+			data:          `{"twentyfourseven": false}`,
+			version:       OCPIVersion221,
+			expectedError: fmt.Errorf("invalid hours: expected regular hours when twentyfourseven is false"),
+		},
+		"when no regular hours in 3.0": {
+			data:           `{"twentyfourseven": false}`,
+			version:        OCPIVersion30,
+			expectedResult: "",
+		},
+		"when version unsupported": {
+			data:          `{"twentyfourseven": true}`,
+			version:       "2.0",
+			expectedError: fmt.Errorf("unsupported OCPI version `2.0`"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := UnmarshalOCPIHours([]byte(tt.data), tt.version)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestGetOCPIOpeningTimesWithOptions(t *testing.T) {
+	normalize := OCPIOptions{Normalize: true}
+
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		opts           OCPIOptions
+		expectedResult OCPIOpeningTimes
+		expectedError  error
+	}{
+		"when unsorted and fragmented": {
+			openingHours: mustParseOpeningHours("W2T13:00:00/W2T18:00:00,W1T08:00:00/W1T12:00:00,W2T08:00:00/W2T13:00:00,W1T10:00:00/W1T14:00:00"),
+			opts:         normalize,
+			expectedResult: OCPIOpeningTimes{
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "14:00"},
+					{Weekday: 2, PeriodBegin: "08:00", PeriodEnd: "18:00"},
+				},
+			},
+		},
+		"when unsorted and fragmented without normalizing": {
+			openingHours: mustParseOpeningHours("W2T13:00:00/W2T18:00:00,W1T08:00:00/W1T12:00:00,W2T08:00:00/W2T13:00:00"),
+			opts:         DefaultOCPIOptions,
+			expectedResult: OCPIOpeningTimes{
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 2, PeriodBegin: "13:00", PeriodEnd: "18:00"},
+					{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "12:00"},
+					{Weekday: 2, PeriodBegin: "08:00", PeriodEnd: "13:00"},
+				},
+			},
+		},
+		"when spanning several days": {
+			openingHours: mustParseOpeningHours("W3T00:00:00/W3T24:00:00,W1T20:00:00/W3T02:00:00"),
+			opts:         normalize,
+			expectedResult: OCPIOpeningTimes{
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 1, PeriodBegin: "20:00", PeriodEnd: "00:00"},
+					{Weekday: 2, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 3, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+				},
+			},
+		},
+		"when wrapping around the end of the week": {
+			openingHours: mustParseOpeningHours("W7T22:00:00/W1T06:00:00,W1T08:00:00/W1T12:00:00"),
+			opts:         normalize,
+			expectedResult: OCPIOpeningTimes{
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 1, PeriodBegin: "00:00", PeriodEnd: "06:00"},
+					{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "12:00"},
+					{Weekday: 7, PeriodBegin: "22:00", PeriodEnd: "00:00"},
+				},
+			},
+		},
+		"when rounding makes periods overlap": {
+			openingHours: []OpeningHours{
+				{Open: &TimeInWeek{Weekday: 4, MinutesSinceMidnight: 480}, Close: &TimeInWeek{Weekday: 4, MinutesSinceMidnight: 720, Seconds: 10}},
+				{Open: &TimeInWeek{Weekday: 4, MinutesSinceMidnight: 720, Seconds: 40}, Close: &TimeInWeek{Weekday: 4, MinutesSinceMidnight: 960}},
+			},
+			opts: normalize,
+			expectedResult: OCPIOpeningTimes{
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 4, PeriodBegin: "08:00", PeriodEnd: "16:00"},
+				},
+			},
+		},
+		"when 24/7 from fragments": {
+			openingHours:   mustParseOpeningHours("W1T00:00:00/W4T00:00:00,W4T00:00:00/W7T24:00:00"),
+			opts:           normalize,
+			expectedResult: OCPIOpeningTimes{TwentyFourSeven: true},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			opts:           normalize,
+			expectedResult: OCPIOpeningTimes{},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetOCPIOpeningTimesWithOptions(tt.openingHours, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestMarshalOCPIHoursWithOptions(t *testing.T) {
+	t.Parallel()
+
+	ohs := mustParseOpeningHours("W2T13:00:00/W2T18:00:00,W1T20:00:00/W3T02:00:00")
+
+	result, err := MarshalOCPIHoursWithOptions(ohs, OCPIVersion221, OCPIOptions{Normalize: true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"twentyfourseven": false,
+		"regular_hours": [
+			{"weekday": 1, "period_begin": "20:00", "period_end": "23:59"},
+			{"weekday": 2, "period_begin": "00:00", "period_end": "23:59"},
+			{"weekday": 3, "period_begin": "00:00", "period_end": "02:00"}
+		]
+	}`, string(result))
+}
+
+func TestValidateOCPIRegularHours(t *testing.T) {
+	tests := map[string]struct {
+		regularHours  []OCPIRegularHours
+		version       OCPIVersion
+		sorted        bool
+		expectedError error
+	}{
+		"when valid": {
+			regularHours: []OCPIRegularHours{{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "00:00"}, {Weekday: 2, PeriodBegin: "00:00", PeriodEnd: "02:00"}},
+			version:      OCPIVersion30,
+			sorted:       true,
+		},
+		"when not sorted": {
+			regularHours:  []OCPIRegularHours{{Weekday: 2, PeriodBegin: "08:00", PeriodEnd: "12:00"}, {Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "12:00"}},
+			version:       OCPIVersion30,
+			sorted:        true,
+			expectedError: fmt.Errorf("invalid regular hours #2: expected to be after regular hours #1"),
+		},
+		"when not sorted and order not checked": {
+			regularHours: []OCPIRegularHours{{Weekday: 2, PeriodBegin: "08:00", PeriodEnd: "12:00"}, {Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "12:00"}},
+			version:      OCPIVersion30,
+		},
+		"when overlapping": {
+			regularHours:  []OCPIRegularHours{{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "12:00"}, {Weekday: 1, PeriodBegin: "11:00", PeriodEnd: "14:00"}},
+			version:       OCPIVersion211,
+			sorted:        true,
+			expectedError: fmt.Errorf("invalid regular hours #2: expected to be after regular hours #1"),
+		},
+		"when midnight not valid in version": {
+			regularHours:  []OCPIRegularHours{{Weekday: 1, PeriodBegin: "08:00", PeriodEnd: "00:00"}},
+			version:       OCPIVersion221,
+			expectedError: fmt.Errorf("invalid regular hours #1: invalid period `08:00-00:00`: expected period_end to be later than period_begin"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateOCPIRegularHours(tt.regularHours, tt.version, tt.sorted)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
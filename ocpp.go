@@ -0,0 +1,132 @@
+package openinghours
+
+import (
+	"fmt"
+	"time"
+)
+
+// Values of the OCPP ChargingProfile fields making the charging schedule recur every week.
+const (
+	OCPPChargingProfileKindRecurring = "Recurring"
+	OCPPRecurrencyKindWeekly         = "Weekly"
+)
+
+// Units in which the limits of an OCPP charging schedule are given.
+const (
+	OCPPChargingRateUnitW = "W"
+	OCPPChargingRateUnitA = "A"
+)
+
+// OCPPScheduleOptions configures the charging schedule generated by GetOCPPChargingSchedule.
+type OCPPScheduleOptions struct {
+	// Location is the time zone in which the opening hours apply.
+	Location *time.Location
+
+	// Start is an instant in the first week of the schedule. The schedule starts on monday 00:00
+	// of that week.
+	Start time.Time
+
+	// ChargingRateUnit is the unit of the limits, W or A. Defaults to W when empty.
+	ChargingRateUnit string
+
+	// OpenLimit is the limit while open, ClosedLimit is the limit while closed, eg. 0 to stop
+	// offering power outside the opening hours. OpenLimit must be positive unless the opening hours
+	// are never open, as 0 would stop offering power during the opening hours as well.
+	OpenLimit   float64
+	ClosedLimit float64
+}
+
+// OCPPChargingSchedule represents the ChargingSchedule class of OCPP 1.6 and 2.0.1, to be sent in a
+// ChargingProfile with chargingProfileKind Recurring and recurrencyKind Weekly.
+type OCPPChargingSchedule struct {
+	ID                     int                          `json:"id,omitempty"` // Only used, and required, in OCPP 2.0.1.
+	StartSchedule          time.Time                    `json:"startSchedule" example:"2026-10-18T22:00:00Z"`
+	Duration               int                          `json:"duration" example:"604800"` // Seconds
+	ChargingRateUnit       string                       `json:"chargingRateUnit" example:"W"`
+	ChargingSchedulePeriod []OCPPChargingSchedulePeriod `json:"chargingSchedulePeriod"`
+}
+
+type OCPPChargingSchedulePeriod struct {
+	StartPeriod int     `json:"startPeriod" example:"28800"` // Seconds since startSchedule
+	Limit       float64 `json:"limit" example:"22000"`
+}
+
+// GetOCPPChargingSchedule returns a weekly recurring charging schedule applying opts.OpenLimit
+// while open and opts.ClosedLimit while closed. The schedule starts on monday 00:00 of the week
+// containing opts.Start in opts.Location, and lasts a week. Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00")
+//	schedule, _ := GetOCPPChargingSchedule(ohs, OCPPScheduleOptions{Location: time.UTC, Start: now, OpenLimit: 22000})
+//	// schedule.ChargingSchedulePeriod will be:
+//	[]OCPPChargingSchedulePeriod{
+//	    {StartPeriod: 0, Limit: 0},
+//	    {StartPeriod: 28800, Limit: 22000},
+//	    {StartPeriod: 64800, Limit: 0},
+//	}
+//
+// The periods are computed for the instants at which the clocks show the opening and closing
+// times during the first week, see Occurrences. As the charging station repeats the schedule every
+// 168 hours, it is an hour off after a daylight saving time transition. Send a new charging
+// profile, eg. with validFrom and validTo set around the transitions, to keep following the
+// opening hours.
+//
+// An error is returned when the time zone is missing, the unit is invalid, a limit is negative, the
+// open limit is zero while the opening hours are open at some point, or any of the opening hours
+// is invalid.
+func GetOCPPChargingSchedule(ohs []OpeningHours, opts OCPPScheduleOptions) (OCPPChargingSchedule, error) {
+	if opts.Location == nil {
+		return OCPPChargingSchedule{}, fmt.Errorf("missing time zone")
+	}
+	unit := opts.ChargingRateUnit
+	if unit == "" {
+		unit = OCPPChargingRateUnitW
+	}
+	if unit != OCPPChargingRateUnitW && unit != OCPPChargingRateUnitA {
+		return OCPPChargingSchedule{}, fmt.Errorf("invalid charging rate unit `%s`: expected to be W or A", unit)
+	}
+	if opts.OpenLimit < 0 {
+		return OCPPChargingSchedule{}, fmt.Errorf("invalid open limit `%g`: expected to be non-negative", opts.OpenLimit)
+	}
+	if opts.OpenLimit == 0 && len(weekIntervals(ohs)) > 0 {
+		return OCPPChargingSchedule{}, fmt.Errorf("invalid open limit `0`: expected to be positive when the opening hours are open")
+	}
+	if opts.ClosedLimit < 0 {
+		return OCPPChargingSchedule{}, fmt.Errorf("invalid closed limit `%g`: expected to be non-negative", opts.ClosedLimit)
+	}
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return OCPPChargingSchedule{}, err
+		}
+	}
+
+	start := inLocation(startOfWeek(opts.Start, opts.Location), opts.Location)
+
+	var periods []OCPPChargingSchedulePeriod
+	add := func(offset time.Duration, limit float64) {
+		startPeriod := int(offset / time.Second)
+		// A period starting at the same time as the previous one replaces it, and a period with the
+		// same limit as the previous one extends it.
+		if n := len(periods); n > 0 && periods[n-1].StartPeriod == startPeriod {
+			periods = periods[:n-1]
+		}
+		if n := len(periods); n > 0 && periods[n-1].Limit == limit {
+			return
+		}
+		periods = append(periods, OCPPChargingSchedulePeriod{StartPeriod: startPeriod, Limit: limit})
+	}
+
+	add(0, opts.ClosedLimit)
+	for period := range Occurrences(ohs, start, start.Add(week), opts.Location) {
+		add(period.Start.Sub(start), opts.OpenLimit)
+		if end := period.End.Sub(start); end < week {
+			add(end, opts.ClosedLimit)
+		}
+	}
+
+	return OCPPChargingSchedule{
+		StartSchedule:          start.UTC(),
+		Duration:               int(week / time.Second),
+		ChargingRateUnit:       unit,
+		ChargingSchedulePeriod: periods,
+	}, nil
+}
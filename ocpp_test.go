@@ -0,0 +1,174 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOCPPChargingSchedule(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		opts           OCPPScheduleOptions
+		expectedResult OCPPChargingSchedule
+		expectedError  error
+	}{
+		"when open on weekdays": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00"),
+			opts:         OCPPScheduleOptions{Location: time.UTC, Start: time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC), OpenLimit: 22000},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Duration:         604800,
+				ChargingRateUnit: "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{
+					{StartPeriod: 0, Limit: 0},
+					{StartPeriod: 28800, Limit: 22000},
+					{StartPeriod: 64800, Limit: 0},
+					{StartPeriod: 115200, Limit: 22000},
+					{StartPeriod: 151200, Limit: 0},
+				},
+			},
+		},
+		"when overnight around the end of the week": {
+			openingHours: mustParseOpeningHours("W7T22:00:00/W1T06:00:00"),
+			opts:         OCPPScheduleOptions{Location: time.UTC, Start: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), ChargingRateUnit: "A", OpenLimit: 32, ClosedLimit: 6},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Duration:         604800,
+				ChargingRateUnit: "A",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{
+					{StartPeriod: 0, Limit: 32},
+					{StartPeriod: 21600, Limit: 6},
+					{StartPeriod: 597600, Limit: 32},
+				},
+			},
+		},
+		"when in time zone": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00"),
+			opts:         OCPPScheduleOptions{Location: amsterdam, Start: time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC), OpenLimit: 11000},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:    time.Date(2026, 10, 11, 22, 0, 0, 0, time.UTC),
+				Duration:         604800,
+				ChargingRateUnit: "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{
+					{StartPeriod: 0, Limit: 0},
+					{StartPeriod: 28800, Limit: 11000},
+					{StartPeriod: 64800, Limit: 0},
+				},
+			},
+		},
+		"when clocks go back during the week": {
+			openingHours: mustParseOpeningHours("W7T08:00:00/W7T18:00:00"),
+			opts:         OCPPScheduleOptions{Location: amsterdam, Start: time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC), OpenLimit: 11000},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:    time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC),
+				Duration:         604800,
+				ChargingRateUnit: "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{
+					{StartPeriod: 0, Limit: 0},
+					{StartPeriod: 550800, Limit: 11000},
+					{StartPeriod: 586800, Limit: 0},
+				},
+			},
+		},
+		"when 24/7": {
+			openingHours: []OpeningHours{TwentyFourSevenOH},
+			opts:         OCPPScheduleOptions{Location: time.UTC, Start: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), OpenLimit: 22000},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:          time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Duration:               604800,
+				ChargingRateUnit:       "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{{StartPeriod: 0, Limit: 22000}},
+			},
+		},
+		"when never open": {
+			openingHours: []OpeningHours{},
+			opts:         OCPPScheduleOptions{Location: time.UTC, Start: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), OpenLimit: 22000},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:          time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Duration:               604800,
+				ChargingRateUnit:       "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{{StartPeriod: 0, Limit: 0}},
+			},
+		},
+		"when time zone missing": {
+			openingHours:  []OpeningHours{TwentyFourSevenOH},
+			opts:          OCPPScheduleOptions{OpenLimit: 22000},
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+		"when unit invalid": {
+			openingHours:  []OpeningHours{TwentyFourSevenOH},
+			opts:          OCPPScheduleOptions{Location: time.UTC, ChargingRateUnit: "kW", OpenLimit: 22},
+			expectedError: fmt.Errorf("invalid charging rate unit `kW`: expected to be W or A"),
+		},
+		"when limit negative": {
+			openingHours:  []OpeningHours{TwentyFourSevenOH},
+			opts:          OCPPScheduleOptions{Location: time.UTC, OpenLimit: 22000, ClosedLimit: -1},
+			expectedError: fmt.Errorf("invalid closed limit `-1`: expected to be non-negative"),
+		},
+		"when open limit zero": {
+			openingHours:  []OpeningHours{TwentyFourSevenOH},
+			opts:          OCPPScheduleOptions{Location: time.UTC, ClosedLimit: 6},
+			expectedError: fmt.Errorf("invalid open limit `0`: expected to be positive when the opening hours are open"),
+		},
+		"when open limit zero and never open": {
+			openingHours: []OpeningHours{},
+			opts:         OCPPScheduleOptions{Location: time.UTC, Start: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), ClosedLimit: 6},
+			expectedResult: OCPPChargingSchedule{
+				StartSchedule:          time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Duration:               604800,
+				ChargingRateUnit:       "W",
+				ChargingSchedulePeriod: []OCPPChargingSchedulePeriod{{StartPeriod: 0, Limit: 6}},
+			},
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			opts:          OCPPScheduleOptions{Location: time.UTC, OpenLimit: 22000},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetOCPPChargingSchedule(tt.openingHours, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestOCPPChargingScheduleJSON(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := GetOCPPChargingSchedule(mustParseOpeningHours("W1T08:00:00/W1T18:00:00"), OCPPScheduleOptions{
+		Location:  mustLoadLocation("Europe/Amsterdam"),
+		Start:     time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC),
+		OpenLimit: 22000,
+	})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(schedule)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"startSchedule": "2026-10-11T22:00:00Z",
+		"duration": 604800,
+		"chargingRateUnit": "W",
+		"chargingSchedulePeriod": [
+			{"startPeriod": 0, "limit": 0},
+			{"startPeriod": 28800, "limit": 22000},
+			{"startPeriod": 64800, "limit": 0}
+		]
+	}`, string(data))
+}
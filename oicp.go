@@ -0,0 +1,177 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Days of the week to which OICP opening times apply, besides the names of the weekdays.
+const (
+	OICPEveryday = "Everyday"
+	OICPWorkdays = "Workdays"
+	OICPWeekend  = "Weekend"
+)
+
+// oicpWeekdays are the names of the weekdays in the OICP spec, monday first.
+var oicpWeekdays = [...]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// OICPOpeningHours are the opening hours of an EVSE in the OICP spec. Embed it in EVSEData so that
+// its fields are marshaled next to the other fields of the EVSE.
+type OICPOpeningHours struct {
+	IsOpen24Hours bool               `json:"IsOpen24Hours" example:"false"`
+	OpeningTimes  []OICPOpeningTimes `json:"OpeningTimes,omitempty"`
+}
+
+type OICPOpeningTimes struct {
+	Period []OICPPeriod `json:"Period"`
+	On     string       `json:"on" example:"Workdays"` // Monday to Sunday, Workdays (monday to friday), Weekend (saturday and sunday) or Everyday.
+}
+
+type OICPPeriod struct {
+	Begin string `json:"begin" example:"08:00"`
+	End   string `json:"end" example:"18:00"` // "00:00" signals that the EVSE is open until midnight at the end of the day.
+}
+
+// GetOICPOpeningHours returns the OICP opening hours corresponding to the given opening hours.
+// If the opening hours are 24/7, it returns an OICPOpeningHours with IsOpen24Hours set to true.
+// Otherwise the opening times are given per day, with ranges running past midnight being split
+// at midnight. Days with the same periods are grouped as Everyday, Workdays or Weekend.
+// Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00,...,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00")
+//	oicpOpeningHours, _ := GetOICPOpeningHours(ohs)
+//	// oicpOpeningHours will be:
+//	OICPOpeningHours{
+//	    IsOpen24Hours: false,
+//	    OpeningTimes: []OICPOpeningTimes{
+//	        {Period: []OICPPeriod{{Begin: "08:00", End: "18:00"}}, On: "Workdays"},
+//	        {Period: []OICPPeriod{{Begin: "10:00", End: "14:00"}}, On: "Saturday"},
+//	    },
+//	}
+//
+// Like GetOCPIOpeningTimes, opening times are rounded down and closing times are rounded up to the
+// minute. An error is returned when any of the opening hours is invalid.
+func GetOICPOpeningHours(ohs []OpeningHours) (OICPOpeningHours, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return OICPOpeningHours{}, err
+		}
+	}
+
+	if isTwentyFourSeven(ohs) {
+		return OICPOpeningHours{IsOpen24Hours: true}, nil
+	}
+
+	var days [7][]OICPPeriod
+	for weekday, periods := range dailyPeriods(ohs) {
+		for _, p := range periods {
+			begin, end := p.minutes()
+			days[weekday] = append(days[weekday], OICPPeriod{
+				Begin: minutesSinceMidnightToTime(begin),
+				End:   minutesSinceMidnightToTime(end % 1440), // midnight at the end of the day is represented as 00:00
+			})
+		}
+	}
+
+	var openingTimes []OICPOpeningTimes
+	add := func(on string, period []OICPPeriod) {
+		if len(period) > 0 {
+			openingTimes = append(openingTimes, OICPOpeningTimes{Period: period, On: on})
+		}
+	}
+
+	sameDays := func(from, to int) bool {
+		for i := from + 1; i <= to; i++ {
+			if !slices.Equal(days[from], days[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if sameDays(0, 6) {
+		add(OICPEveryday, days[0])
+	} else {
+		if sameDays(0, 4) && len(days[0]) > 0 {
+			add(OICPWorkdays, days[0])
+		} else {
+			for weekday := 0; weekday < 5; weekday++ {
+				add(oicpWeekdays[weekday], days[weekday])
+			}
+		}
+
+		if sameDays(5, 6) {
+			add(OICPWeekend, days[5])
+		} else {
+			add(oicpWeekdays[5], days[5])
+			add(oicpWeekdays[6], days[6])
+		}
+	}
+
+	return OICPOpeningHours{IsOpen24Hours: false, OpeningTimes: openingTimes}, nil
+}
+
+// ParseOICPOpeningHours does the opposite of GetOICPOpeningHours. Periods ending at or before
+// their beginning run overnight into the next day, eg. from 22:00 to 06:00.
+func ParseOICPOpeningHours(v OICPOpeningHours) ([]OpeningHours, error) {
+	if v.IsOpen24Hours {
+		return []OpeningHours{TwentyFourSevenOH}, nil
+	}
+
+	var intervals []weekInterval
+	for _, ot := range v.OpeningTimes {
+		weekdays, err := parseOICPDays(ot.On)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range ot.Period {
+			begin, err := parseClockTime(p.Begin)
+			if err != nil {
+				return nil, fmt.Errorf("invalid begin of period on %s: %s", ot.On, err)
+			}
+			if begin == 1440 {
+				return nil, fmt.Errorf("invalid begin of period on %s: expected to be before 24:00", ot.On)
+			}
+			end, err := parseClockTime(p.End)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end of period on %s: %s", ot.On, err)
+			}
+			if end <= begin {
+				end += 1440
+			}
+
+			for _, weekday := range weekdays {
+				midnight := time.Duration(weekday-1) * day
+				intervals = append(intervals, weekInterval{
+					start: midnight + time.Duration(begin)*time.Minute,
+					end:   midnight + time.Duration(end)*time.Minute,
+				})
+			}
+		}
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+// parseOICPDays returns the weekdays, monday being 1, to which OICP opening times apply.
+func parseOICPDays(on string) ([]int, error) {
+	switch {
+	case strings.EqualFold(on, OICPEveryday):
+		return []int{1, 2, 3, 4, 5, 6, 7}, nil
+	case strings.EqualFold(on, OICPWorkdays):
+		return []int{1, 2, 3, 4, 5}, nil
+	case strings.EqualFold(on, OICPWeekend):
+		return []int{6, 7}, nil
+	}
+
+	for i, name := range oicpWeekdays {
+		if strings.EqualFold(on, name) {
+			return []int{i + 1}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid days `%s`: expected to be a weekday, Workdays, Weekend or Everyday", on)
+}
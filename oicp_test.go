@@ -0,0 +1,218 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOICPOpeningHours(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult OICPOpeningHours
+		expectedError  error
+	}{
+		"when 24/7": {
+			openingHours:   []OpeningHours{TwentyFourSevenOH},
+			expectedResult: OICPOpeningHours{IsOpen24Hours: true},
+		},
+		"when workdays and saturday": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W4T08:00:00/W4T18:00:00,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00"),
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "08:00", End: "18:00"}}, On: "Workdays"},
+					{Period: []OICPPeriod{{Begin: "10:00", End: "14:00"}}, On: "Saturday"},
+				},
+			},
+		},
+		"when every day with several periods": {
+			openingHours: mustParseOpeningHours("W1T07:00:00/W1T12:00:00,W1T13:00:00/W1T19:00:00,W2T07:00:00/W2T12:00:00,W2T13:00:00/W2T19:00:00,W3T07:00:00/W3T12:00:00,W3T13:00:00/W3T19:00:00,W4T07:00:00/W4T12:00:00,W4T13:00:00/W4T19:00:00,W5T07:00:00/W5T12:00:00,W5T13:00:00/W5T19:00:00,W6T07:00:00/W6T12:00:00,W6T13:00:00/W6T19:00:00,W7T07:00:00/W7T12:00:00,W7T13:00:00/W7T19:00:00"),
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "07:00", End: "12:00"}, {Begin: "13:00", End: "19:00"}}, On: "Everyday"},
+				},
+			},
+		},
+		"when weekend only": {
+			openingHours: mustParseOpeningHours("W6T09:00:00/W6T17:00:00,W7T09:00:00/W7T17:00:00"),
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "09:00", End: "17:00"}}, On: "Weekend"},
+				},
+			},
+		},
+		"when overnight and whole days": {
+			openingHours: mustParseOpeningHours("W2T09:00:00/W2T17:00:00,W5T22:00:00/W7T04:00:00"),
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "09:00", End: "17:00"}}, On: "Tuesday"},
+					{Period: []OICPPeriod{{Begin: "22:00", End: "00:00"}}, On: "Friday"},
+					{Period: []OICPPeriod{{Begin: "00:00", End: "00:00"}}, On: "Saturday"},
+					{Period: []OICPPeriod{{Begin: "00:00", End: "04:00"}}, On: "Sunday"},
+				},
+			},
+		},
+		"when overnight around the end of the week": {
+			openingHours: mustParseOpeningHours("W7T22:00:00/W1T06:00:00"),
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "00:00", End: "06:00"}}, On: "Monday"},
+					{Period: []OICPPeriod{{Begin: "22:00", End: "00:00"}}, On: "Sunday"},
+				},
+			},
+		},
+		"when seconds": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 3, MinutesSinceMidnight: 480, Seconds: 30},
+				Close: &TimeInWeek{Weekday: 3, MinutesSinceMidnight: 960, Seconds: 15},
+			}},
+			expectedResult: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "08:00", End: "16:01"}}, On: "Wednesday"},
+				},
+			},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: OICPOpeningHours{},
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetOICPOpeningHours(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestParseOICPOpeningHours(t *testing.T) {
+	tests := map[string]struct {
+		oicpOpeningHours OICPOpeningHours
+		expectedResult   string
+		expectedError    error
+	}{
+		"when 24/7": {
+			oicpOpeningHours: OICPOpeningHours{IsOpen24Hours: true},
+			expectedResult:   TwentyFourSevenString,
+		},
+		"when workdays and weekend": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "08:00", End: "12:00"}, {Begin: "13:00", End: "18:00"}}, On: "Workdays"},
+					{Period: []OICPPeriod{{Begin: "10:00", End: "14:00"}}, On: "Weekend"},
+				},
+			},
+			expectedResult: "W1T08:00:00/W1T12:00:00,W1T13:00:00/W1T18:00:00,W2T08:00:00/W2T12:00:00,W2T13:00:00/W2T18:00:00,W3T08:00:00/W3T12:00:00,W3T13:00:00/W3T18:00:00,W4T08:00:00/W4T12:00:00,W4T13:00:00/W4T18:00:00,W5T08:00:00/W5T12:00:00,W5T13:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00,W7T10:00:00/W7T14:00:00",
+		},
+		"when overnight": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "22:00", End: "06:00"}}, On: "Sunday"},
+					{Period: []OICPPeriod{{Begin: "18:00", End: "00:00"}}, On: "friday"},
+				},
+			},
+			expectedResult: "W5T18:00:00/W5T24:00:00,W7T22:00:00/W1T06:00:00",
+		},
+		"when split at midnight": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "22:00", End: "00:00"}}, On: "Friday"},
+					{Period: []OICPPeriod{{Begin: "00:00", End: "00:00"}}, On: "Saturday"},
+					{Period: []OICPPeriod{{Begin: "00:00", End: "04:00"}}, On: "Sunday"},
+				},
+			},
+			expectedResult: "W5T22:00:00/W7T04:00:00",
+		},
+		"when every day all day": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{
+					{Period: []OICPPeriod{{Begin: "00:00", End: "24:00"}}, On: "Everyday"},
+				},
+			},
+			expectedResult: TwentyFourSevenString,
+		},
+		"when no opening times": {
+			oicpOpeningHours: OICPOpeningHours{},
+			expectedResult:   "",
+		},
+		"when days invalid": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{{Period: []OICPPeriod{{Begin: "08:00", End: "18:00"}}, On: "Holidays"}},
+			},
+			expectedError: fmt.Errorf("invalid days `Holidays`: expected to be a weekday, Workdays, Weekend or Everyday"),
+		},
+		"when begin invalid": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{{Period: []OICPPeriod{{Begin: "8:00", End: "18:00"}}, On: "Monday"}},
+			},
+			expectedError: fmt.Errorf("invalid begin of period on Monday: invalid time `8:00`: expected to be formatted as hh:mm"),
+		},
+		"when begin at end of day": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{{Period: []OICPPeriod{{Begin: "24:00", End: "06:00"}}, On: "Monday"}},
+			},
+			expectedError: fmt.Errorf("invalid begin of period on Monday: expected to be before 24:00"),
+		},
+		"when end invalid": {
+			oicpOpeningHours: OICPOpeningHours{
+				OpeningTimes: []OICPOpeningTimes{{Period: []OICPPeriod{{Begin: "08:00", End: "18:60"}}, On: "Monday"}},
+			},
+			expectedError: fmt.Errorf("invalid end of period on Monday: invalid time `18:60`: invalid minutes value"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseOICPOpeningHours(tt.oicpOpeningHours)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestOICPOpeningHoursJSON(t *testing.T) {
+	t.Parallel()
+
+	var evse struct {
+		EvseID string `json:"EvseID"`
+		OICPOpeningHours
+	}
+	err := json.Unmarshal([]byte(`{
+		"EvseID": "DE*ICE*E1234567*1",
+		"IsOpen24Hours": false,
+		"OpeningTimes": [{"Period": [{"begin": "08:00", "end": "20:00"}], "on": "Workdays"}]
+	}`), &evse)
+	assert.NoError(t, err)
+
+	ohs, err := ParseOICPOpeningHours(evse.OICPOpeningHours)
+	assert.NoError(t, err)
+	assert.Equal(t, "W1T08:00:00/W1T20:00:00,W2T08:00:00/W2T20:00:00,W3T08:00:00/W3T20:00:00,W4T08:00:00/W4T20:00:00,W5T08:00:00/W5T20:00:00", OpeningHoursSliceToString(ohs))
+
+	evse.OICPOpeningHours, err = GetOICPOpeningHours([]OpeningHours{TwentyFourSevenOH})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(evse)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"EvseID": "DE*ICE*E1234567*1", "IsOpen24Hours": true}`, string(data))
+}
@@ -2,7 +2,6 @@ package openinghours
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -37,6 +36,12 @@ var (
 	}
 )
 
+// OpeningHours is a range of time within the week during which something is open. A range whose
+// closing time is before its opening time wraps around the end of the week.
+//
+// Either side of the range may be left out, in which case the range is open-ended: without Open,
+// the range starts at the beginning of the week (monday 00:00) and without Close, it lasts until
+// the end of the week (sunday 24:00). A range without both is empty.
 type OpeningHours struct {
 	Open  *TimeInWeek
 	Close *TimeInWeek
@@ -44,7 +49,8 @@ type OpeningHours struct {
 
 // String returns the opening hours of the amenity in a string. Unfortunately, there are no formats
 // in either standards (RFC 3339 or ISO 8601) to represent a recurring time within a given week, so
-// one is invented here.
+// one is invented here. See GetISO8601RepeatingIntervals for ISO 8601 repeating intervals
+// anchored at a given week.
 //
 // Using the same example as above, the resulting strings would be
 // * "W2T06:00:00/W2T20:00:00"; and
@@ -53,31 +59,51 @@ type OpeningHours struct {
 // Contrary to the stdlib's time, the start of the week is monday, to follow RFC 3339.
 //
 // No time zone information is provided, as the opening hours are static within the given day, ie.
-// they don't change during a daylight saving time change.
+// they don't change during a daylight saving time change. See Occurrences for how the opening
+// hours are mapped onto actual instants around such changes.
 func (oh OpeningHours) String() string {
 	var open string
 	if oh.Open != nil {
 		open = fmt.Sprintf(
-			"W%dT%02d:%02d:00",
+			"W%dT%02d:%02d:%02d",
 			oh.Open.Weekday,
 			oh.Open.MinutesSinceMidnight/60,
 			oh.Open.MinutesSinceMidnight%60,
+			oh.Open.Seconds,
 		)
 	}
 
 	var close string
 	if oh.Close != nil {
 		close = fmt.Sprintf(
-			"W%dT%02d:%02d:00",
+			"W%dT%02d:%02d:%02d",
 			oh.Close.Weekday,
 			oh.Close.MinutesSinceMidnight/60,
 			oh.Close.MinutesSinceMidnight%60,
+			oh.Close.Seconds,
 		)
 	}
 
 	return fmt.Sprintf("%s/%s", open, close)
 }
 
+// Validate returns an error when the opening or closing time is out of range.
+func (oh OpeningHours) Validate() error {
+	if oh.Open != nil {
+		if err := oh.Open.validate(); err != nil {
+			return fmt.Errorf("invalid opening hours: %s", err)
+		}
+	}
+
+	if oh.Close != nil {
+		if err := oh.Close.validate(); err != nil {
+			return fmt.Errorf("invalid closing hours: %s", err)
+		}
+	}
+
+	return nil
+}
+
 // OpeningHoursSliceToString converts a slice of OpeningHours into a single string representation like "W1T08:00:00/W1T16:00:00,W2T06:00:00/W2T20:00:00".
 func OpeningHoursSliceToString(ohs []OpeningHours) string {
 	openingHoursStr := make([]string, len(ohs))
@@ -90,39 +116,11 @@ func OpeningHoursSliceToString(ohs []OpeningHours) string {
 
 // ParseOpeningHours does the opposite of OpeningHours.String method. It converts a string like
 // "W0T08:00:00/W0T20:00:00" into a []OpeningHours.
+//
+// It parses with DefaultParseOptions, see ParseOpeningHoursWithOptions for stricter or more
+// lenient parsing.
 func ParseOpeningHours(v string) ([]OpeningHours, error) {
-	strs := strings.Split(v, ",")
-
-	ohs := make([]OpeningHours, 0, len(strs))
-	for _, str := range strs {
-		if str == "" {
-			continue
-		}
-
-		parts := strings.Split(str, "/")
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid opening hours string `%s`", str)
-		}
-
-		openingHours, err := parseTimeInWeek(parts[0])
-		if err != nil {
-			return nil, fmt.Errorf("invalid opening hours: %s", err)
-		}
-
-		closingHours, err := parseTimeInWeek(parts[1])
-		if err != nil {
-			return nil, fmt.Errorf("invalid closing hours: %s", err)
-		}
-
-		oh := OpeningHours{
-			Open:  openingHours,
-			Close: closingHours,
-		}
-
-		ohs = append(ohs, oh)
-	}
-
-	return ohs, nil
+	return ParseOpeningHoursWithOptions(v, DefaultParseOptions)
 }
 
 type TimeRange struct {
@@ -144,30 +142,34 @@ type TimeRange struct {
 //	 Friday: [{open: "10:00", close: "12:00"}, {open: "13:00", close: "21:00"}]
 //	 ...
 //	}
-func GetHumanReadableTimes(ohs []OpeningHours) map[string][]TimeRange {
+//
+// The times are given to the minute: opening times are rounded down and closing times are rounded
+// up, so that the ranges are never shortened.
+//
+// Open-ended opening hours are handled as described in OpeningHours. An error is returned when
+// any of the opening hours is invalid.
+func GetHumanReadableTimes(ohs []OpeningHours) (map[string][]TimeRange, error) {
 	if len(ohs) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	openingTimes := make(map[string][]TimeRange)
 	for _, oh := range ohs {
-		if oh.Close.MinutesSinceMidnight == 0 {
-			setPreviousDay(&oh.Close.Weekday)
-			oh.Close.MinutesSinceMidnight = 1440 // 24:00
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+
+		wi, ok := oh.interval()
+		if !ok {
+			continue
 		}
-		if oh.Open.Weekday == oh.Close.Weekday {
-			addTimeToWeek(openingTimes, getWeekDay(oh.Open.Weekday), minutesSinceMidnightToTime(oh.Open.MinutesSinceMidnight), minutesSinceMidnightToTime(oh.Close.MinutesSinceMidnight))
-		} else {
-			addTimeToWeek(openingTimes, getWeekDay(oh.Open.Weekday), minutesSinceMidnightToTime(oh.Open.MinutesSinceMidnight), "24:00")
-			setNextDay(&oh.Open.Weekday)
-			for oh.Open.Weekday != oh.Close.Weekday {
-				addTimeToWeek(openingTimes, getWeekDay(oh.Open.Weekday), "00:00", "24:00")
-				setNextDay(&oh.Open.Weekday)
-			}
-			addTimeToWeek(openingTimes, getWeekDay(oh.Close.Weekday), "00:00", minutesSinceMidnightToTime(oh.Close.MinutesSinceMidnight))
+
+		for weekday, p := range wi.days() {
+			begin, end := p.minutes()
+			addTimeToWeek(openingTimes, getWeekDay(weekday+1), minutesSinceMidnightToTime(begin), minutesSinceMidnightToTime(end))
 		}
 	}
-	return openingTimes
+	return openingTimes, nil
 }
 
 func addTimeToWeek(times map[string][]TimeRange, weekday string, openingTime string, closingTime string) {
@@ -188,80 +190,63 @@ type OCPIRegularHours struct {
 // GetOCPIOpeningTimes converts a slice of OpeningHours into an OCPIOpeningTimes struct.
 // If the opening hours are 24/7, it returns an OCPIOpeningTimes with TwentyFourSeven set to true.
 // Example:
-//   ohs := []OpeningHours{
-//       {Open: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 0}, Close: &TimeInWeek{Weekday: 7, minutesSinceMidnight: 1440}},
-//   }
-//   ocpiOpeningTimes := GetOCPIOpeningTimes(ohs)
-//   // ocpiOpeningTimes will be OCPIOpeningTimes{TwentyFourSeven: true}
+//
+//	ohs := []OpeningHours{
+//	    {Open: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 0}, Close: &TimeInWeek{Weekday: 7, minutesSinceMidnight: 1440}},
+//	}
+//	ocpiOpeningTimes, err := GetOCPIOpeningTimes(ohs)
+//	// ocpiOpeningTimes will be OCPIOpeningTimes{TwentyFourSeven: true}
 //
 // If the opening hours are not 24/7, it returns an OCPIOpeningTimes with
 // RegularHours containing the opening and closing times for each day of the week.
 // Example:
-//   ohs := []OpeningHours{
-//       {Open: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 360}, Close: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 1200}},
-//       {Open: &TimeInWeek{Weekday: 5, minutesSinceMidnight: 630}, Close: &TimeInWeek{Weekday: 5, minutesSinceMidnight: 780}},
-//   }
-//   ocpiOpeningTimes := GetOCPIOpeningTimes(ohs)
-//   // ocpiOpeningTimes will be OCPIOpeningTimes{
-//       TwentyFourSeven: false,
-//       RegularHours: &[]OCPIRegularHours{
-//           {Weekday: 1, PeriodBegin: "06:00", PeriodEnd: "20:00"},
-//           {Weekday: 5, PeriodBegin: "10:30", PeriodEnd: "13:00"},
-//       },
-//   }
 //
+//	ohs := []OpeningHours{
+//	    {Open: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 360}, Close: &TimeInWeek{Weekday: 1, minutesSinceMidnight: 1200}},
+//	    {Open: &TimeInWeek{Weekday: 5, minutesSinceMidnight: 630}, Close: &TimeInWeek{Weekday: 5, minutesSinceMidnight: 780}},
+//	}
+//	ocpiOpeningTimes, err := GetOCPIOpeningTimes(ohs)
+//	// ocpiOpeningTimes will be OCPIOpeningTimes{
+//	    TwentyFourSeven: false,
+//	    RegularHours: &[]OCPIRegularHours{
+//	        {Weekday: 1, PeriodBegin: "06:00", PeriodEnd: "20:00"},
+//	        {Weekday: 5, PeriodBegin: "10:30", PeriodEnd: "13:00"},
+//	    },
+//	}
+//
+// As the OCPI spec only has times to the minute, opening times are rounded down and closing times
+// are rounded up, so that the ranges are never shortened.
+//
+// Open-ended opening hours are handled as described in OpeningHours. An error is returned when
+// any of the opening hours is invalid.
+//
+// The regular hours follow the order of the opening hours. Use GetOCPIOpeningTimesWithOptions to
+// have them sorted and merged.
+func GetOCPIOpeningTimes(ohs []OpeningHours) (OCPIOpeningTimes, error) {
+	return GetOCPIOpeningTimesWithOptions(ohs, DefaultOCPIOptions)
+}
 
-func GetOCPIOpeningTimes(ohs []OpeningHours) OCPIOpeningTimes {
-	if isTwentyFourSeven(ohs) {
-		return OCPIOpeningTimes{TwentyFourSeven: true}
-	}
-
+// ocpiRegularHours returns the regular hours of the opening hours as in OCPI 3.0, with one or more
+// regular hours per range in the order of the opening hours.
+func ocpiRegularHours(ohs []OpeningHours) []OCPIRegularHours {
 	var regularHours []OCPIRegularHours
 	for _, oh := range ohs {
-		switch oh.Close.MinutesSinceMidnight {
-		case 0:
-			setPreviousDay(&oh.Close.Weekday)
-		case 1440:
-			oh.Close.MinutesSinceMidnight = 0 // 24:00 is represented as 00:00 in the OCPI spec
+		wi, ok := oh.interval()
+		if !ok {
+			continue
 		}
 
-		if oh.Open.Weekday == oh.Close.Weekday {
-			regularHours = append(regularHours, OCPIRegularHours{
-				Weekday:     oh.Open.Weekday,
-				PeriodBegin: minutesSinceMidnightToTime(oh.Open.MinutesSinceMidnight),
-				PeriodEnd:   minutesSinceMidnightToTime(oh.Close.MinutesSinceMidnight),
-			})
-			continue
-		} else {
-			regularHours = append(regularHours, OCPIRegularHours{
-				Weekday:     oh.Open.Weekday,
-				PeriodBegin: minutesSinceMidnightToTime(oh.Open.MinutesSinceMidnight),
-				PeriodEnd:   "00:00",
-			})
-			setNextDay(&oh.Open.Weekday)
-			for oh.Open.Weekday != oh.Close.Weekday {
-				regularHours = append(regularHours, OCPIRegularHours{
-					Weekday:     oh.Open.Weekday,
-					PeriodBegin: "00:00",
-					PeriodEnd:   "00:00",
-				})
-				setNextDay(&oh.Open.Weekday)
-			}
+		for weekday, p := range wi.days() {
+			begin, end := p.minutes()
 			regularHours = append(regularHours, OCPIRegularHours{
-				Weekday:     oh.Close.Weekday,
-				PeriodBegin: "00:00",
-				PeriodEnd:   minutesSinceMidnightToTime(oh.Close.MinutesSinceMidnight),
+				Weekday:     weekday + 1,
+				PeriodBegin: minutesSinceMidnightToTime(begin),
+				PeriodEnd:   minutesSinceMidnightToTime(end % 1440), // 24:00 is represented as 00:00 in the OCPI spec
 			})
 		}
 	}
-	if len(regularHours) == 0 {
-		return OCPIOpeningTimes{}
-	}
 
-	return OCPIOpeningTimes{
-		TwentyFourSeven: false,
-		RegularHours:    &regularHours,
-	}
+	return regularHours
 }
 
 // ParseStringWeekdayToTimeWeekday converts a string representation of a weekday
@@ -307,19 +292,36 @@ func ParseMinutesSinceMidnight(v1, v2 string) (int, error) {
 	return hours*60 + minutes, nil
 }
 
+// parseClockTime parses a time of the day given as hours and minutes, eg. "08:30", into minutes
+// since midnight.
+func parseClockTime(v string) (int, error) {
+	hours, minutes, ok := strings.Cut(v, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, fmt.Errorf("invalid time `%s`: expected to be formatted as hh:mm", v)
+	}
+
+	m, err := ParseMinutesSinceMidnight(hours, minutes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time `%s`: %s", v, err)
+	}
+
+	return m, nil
+}
+
 func isTwentyFourSeven(ohs []OpeningHours) bool {
 	if len(ohs) == 0 {
 		return false
 	}
 
 	for _, oh := range ohs {
-		if oh.Open == nil || oh.Close == nil {
+		open, close, ok := oh.bounds()
+		if !ok {
 			return false
 		}
-		if oh.Open.Weekday != 1 || oh.Close.Weekday != 7 {
+		if open.Weekday != 1 || close.Weekday != 7 {
 			return false
 		}
-		if oh.Open.MinutesSinceMidnight != 0 || oh.Close.MinutesSinceMidnight != 1440 {
+		if open.MinutesSinceMidnight != 0 || open.Seconds != 0 || close.MinutesSinceMidnight != 1440 {
 			return false
 		}
 	}
@@ -327,22 +329,41 @@ func isTwentyFourSeven(ohs []OpeningHours) bool {
 	return true
 }
 
-// TimeInWeek contains a time within the week, given by the weekday number and the minutes since
-// midnight.
+// TimeInWeek contains a time within the week, given by the weekday number, the minutes since
+// midnight and the seconds within that minute.
 //
 // Note that the Weekday is as per RFC 3339, not stdlib's time.Weekday.
 type TimeInWeek struct {
 	Weekday              int
 	MinutesSinceMidnight int
+	Seconds              int
 }
 
-func parseTimeInWeek(v string) (*TimeInWeek, error) {
+func (tiw TimeInWeek) validate() error {
+	if tiw.Weekday < 1 || tiw.Weekday > 7 {
+		return fmt.Errorf("invalid workday `%d`: expected to be between 1 (monday) and 7 (sunday)", tiw.Weekday)
+	}
+
+	if tiw.MinutesSinceMidnight < 0 || tiw.MinutesSinceMidnight > 1440 {
+		return fmt.Errorf("invalid time `%d`: expected to be between 0 and 1440 minutes since midnight", tiw.MinutesSinceMidnight)
+	}
+
+	if tiw.Seconds < 0 || tiw.Seconds > 59 || (tiw.MinutesSinceMidnight == 1440 && tiw.Seconds != 0) {
+		return fmt.Errorf("invalid seconds `%d` at %s", tiw.Seconds, minutesSinceMidnightToTime(tiw.MinutesSinceMidnight))
+	}
+
+	return nil
+}
+
+func parseTimeInWeek(v string, opts ParseOptions) (*TimeInWeek, error) {
+	if opts.AllowWhitespace {
+		v = strings.TrimSpace(v)
+	}
 	if v == "" {
 		return nil, nil
 	}
 
-	re := regexp.MustCompile(`^W(\d)T(\d{2}):(\d{2}):\d{2}$`)
-	matches := re.FindStringSubmatch(v)
+	matches := opts.timeInWeekRegexp().FindStringSubmatch(v)
 	if len(matches) < 2 {
 		return nil, fmt.Errorf("invalid value `%s`", v)
 	}
@@ -357,9 +378,25 @@ func parseTimeInWeek(v string) (*TimeInWeek, error) {
 		return nil, fmt.Errorf("invalid time in `%s`: %s", v, err)
 	}
 
+	var seconds int
+	if matches[4] != "" {
+		if !opts.AcceptSeconds && matches[4] != "00" {
+			return nil, fmt.Errorf("invalid seconds in `%s`: expected to be 00", v)
+		}
+
+		seconds, err = strconv.Atoi(matches[4])
+		if err != nil || seconds > 59 {
+			return nil, fmt.Errorf("invalid time in `%s`: invalid seconds value", v)
+		}
+		if minutesSinceMidnight == 1440 && seconds != 0 {
+			return nil, fmt.Errorf("invalid time in `%s`: invalid value", v)
+		}
+	}
+
 	tiw := TimeInWeek{
 		Weekday:              weekday,
 		MinutesSinceMidnight: minutesSinceMidnight,
+		Seconds:              seconds,
 	}
 
 	return &tiw, nil
@@ -400,14 +437,3 @@ func minutesSinceMidnightToTime(minutesSinceMidnight int) string {
 	minutes := minutesSinceMidnight % 60
 	return fmt.Sprintf("%02d:%02d", hours, minutes)
 }
-
-func setNextDay(w *int) {
-	*w = *w%7 + 1
-}
-
-func setPreviousDay(w *int) {
-	*w = *w - 1
-	if *w < 1 {
-		*w = 7
-	}
-}
@@ -96,6 +96,13 @@ func TestOpeningHoursString(t *testing.T) {
 			},
 			expectedResult: "W10T08:00:00/W1T16:00:00",
 		},
+		"when seconds specified": {
+			openingHours: OpeningHours{
+				Open:  &TimeInWeek{Weekday: 5, MinutesSinceMidnight: 480, Seconds: 30},
+				Close: &TimeInWeek{Weekday: 5, MinutesSinceMidnight: 1439, Seconds: 59},
+			},
+			expectedResult: "W5T08:00:30/W5T23:59:59",
+		},
 	}
 
 	for name, tt := range tests {
@@ -302,6 +309,16 @@ func TestParseOpeningHours(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		"when seconds specified": {
+			openingHours: "W5T08:00:30/W5T23:59:59",
+			expectedResult: []OpeningHours{
+				{
+					Open:  &TimeInWeek{Weekday: 5, MinutesSinceMidnight: 480, Seconds: 30},
+					Close: &TimeInWeek{Weekday: 5, MinutesSinceMidnight: 1439, Seconds: 59},
+				},
+			},
+			expectedError: nil,
+		},
 		"when string empty": {
 			openingHours:   "",
 			expectedResult: []OpeningHours{},
@@ -357,6 +374,16 @@ func TestParseOpeningHours(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  fmt.Errorf("invalid closing hours: invalid time in `W7T24:01:00`: invalid value"),
 		},
+		"when opening seconds invalid": {
+			openingHours:   "W1T08:00:60/W1T16:00:00",
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("invalid opening hours: invalid time in `W1T08:00:60`: invalid seconds value"),
+		},
+		"when closing seconds after midnight": {
+			openingHours:   "W1T00:00:00/W7T24:00:01",
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("invalid closing hours: invalid time in `W7T24:00:01`: invalid value"),
+		},
 	}
 
 	for name, tt := range tests {
@@ -435,6 +462,44 @@ func TestGetHumanReadableTimes(t *testing.T) {
 				},
 			},
 		},
+		"closes before opening on the same day": {
+			openingHours: "W3T20:00:00/W3T04:00:00",
+			expectedResult: map[string][]TimeRange{
+				"monday":    {{Open: "00:00", Close: "24:00"}},
+				"tuesday":   {{Open: "00:00", Close: "24:00"}},
+				"wednesday": {{Open: "20:00", Close: "24:00"}, {Open: "00:00", Close: "04:00"}},
+				"thursday":  {{Open: "00:00", Close: "24:00"}},
+				"friday":    {{Open: "00:00", Close: "24:00"}},
+				"saturday":  {{Open: "00:00", Close: "24:00"}},
+				"sunday":    {{Open: "00:00", Close: "24:00"}},
+			},
+		},
+		"opening hours not specified": {
+			openingHours: "/W2T16:00:00",
+			expectedResult: map[string][]TimeRange{
+				"monday":  {{Open: "00:00", Close: "24:00"}},
+				"tuesday": {{Open: "00:00", Close: "16:00"}},
+			},
+		},
+		"closing hours not specified": {
+			openingHours: "W7T08:00:00/",
+			expectedResult: map[string][]TimeRange{
+				"sunday": {{Open: "08:00", Close: "24:00"}},
+			},
+		},
+		"opening and closing hours not specified": {
+			openingHours:   "/",
+			expectedResult: map[string][]TimeRange{},
+		},
+		"seconds are rounded": {
+			openingHours: "W5T08:00:30/W5T12:00:01,W5T13:00:00/W5T23:59:59",
+			expectedResult: map[string][]TimeRange{
+				"friday": {
+					{Open: "08:00", Close: "12:01"},
+					{Open: "13:00", Close: "24:00"},
+				},
+			},
+		},
 	}
 	for name, tt := range tests {
 		tt := tt
@@ -443,8 +508,9 @@ func TestGetHumanReadableTimes(t *testing.T) {
 			t.Parallel()
 
 			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+			result, err := GetHumanReadableTimes(ohs)
 			assert.Equal(t, tt.expectedError, err)
-			result := GetHumanReadableTimes(ohs)
 			assert.Equal(t, tt.expectedResult, result)
 		})
 	}
@@ -547,6 +613,70 @@ func TestGetOCPIOpeningTimes(t *testing.T) {
 			openingHours:   "",
 			expectedResult: OCPIOpeningTimes{},
 		},
+		"when closes before opening on the same day": {
+			openingHours: "W3T20:00:00/W3T04:00:00",
+			expectedResult: OCPIOpeningTimes{
+				TwentyFourSeven: false,
+				RegularHours: &[]OCPIRegularHours{
+					{Weekday: 3, PeriodBegin: "20:00", PeriodEnd: "00:00"},
+					{Weekday: 4, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 5, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 6, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 7, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 1, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 2, PeriodBegin: "00:00", PeriodEnd: "00:00"},
+					{Weekday: 3, PeriodBegin: "00:00", PeriodEnd: "04:00"},
+				},
+			},
+		},
+		"when opening hours not specified": {
+			openingHours: "/W1T16:00:00",
+			expectedResult: OCPIOpeningTimes{
+				TwentyFourSeven: false,
+				RegularHours: &[]OCPIRegularHours{
+					{
+						Weekday:     1,
+						PeriodBegin: "00:00",
+						PeriodEnd:   "16:00",
+					},
+				},
+			},
+		},
+		"when closing hours not specified": {
+			openingHours: "W6T20:00:00/",
+			expectedResult: OCPIOpeningTimes{
+				TwentyFourSeven: false,
+				RegularHours: &[]OCPIRegularHours{
+					{
+						Weekday:     6,
+						PeriodBegin: "20:00",
+						PeriodEnd:   "00:00",
+					},
+					{
+						Weekday:     7,
+						PeriodBegin: "00:00",
+						PeriodEnd:   "00:00",
+					},
+				},
+			},
+		},
+		"when opening and closing hours not specified": {
+			openingHours:   "/",
+			expectedResult: OCPIOpeningTimes{},
+		},
+		"when seconds are rounded": {
+			openingHours: "W5T08:00:30/W5T23:59:59",
+			expectedResult: OCPIOpeningTimes{
+				TwentyFourSeven: false,
+				RegularHours: &[]OCPIRegularHours{
+					{
+						Weekday:     5,
+						PeriodBegin: "08:00",
+						PeriodEnd:   "00:00",
+					},
+				},
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -555,13 +685,74 @@ func TestGetOCPIOpeningTimes(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			ohs, _ := ParseOpeningHours(tt.openingHours)
-			result := GetOCPIOpeningTimes(ohs)
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+			result, err := GetOCPIOpeningTimes(ohs)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedResult, result)
 		})
 	}
 }
 
+func TestGetHumanReadableTimesAndOCPIOpeningTimesWhenInvalid(t *testing.T) {
+	tests := map[string]struct {
+		openingHours  []OpeningHours
+		expectedError error
+	}{
+		"when opening weekday invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 10, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `10`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+		"when closing time invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1500},
+			}},
+			expectedError: fmt.Errorf("invalid closing hours: invalid time `1500`: expected to be between 0 and 1440 minutes since midnight"),
+		},
+		"when closing seconds invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960, Seconds: 75},
+			}},
+			expectedError: fmt.Errorf("invalid closing hours: invalid seconds `75` at 16:00"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			humanReadable, err := GetHumanReadableTimes(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Nil(t, humanReadable)
+
+			ocpi, err := GetOCPIOpeningTimes(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, OCPIOpeningTimes{}, ocpi)
+		})
+	}
+}
+
+func TestGetHumanReadableTimesAndOCPIOpeningTimesLeaveInputUnchanged(t *testing.T) {
+	t.Parallel()
+
+	ohs, err := ParseOpeningHours("W1T08:00:00/W3T00:00:00,W5T20:00:00/W5T24:00:00")
+	assert.NoError(t, err)
+
+	_, err = GetHumanReadableTimes(ohs)
+	assert.NoError(t, err)
+	_, err = GetOCPIOpeningTimes(ohs)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "W1T08:00:00/W3T00:00:00,W5T20:00:00/W5T24:00:00", OpeningHoursSliceToString(ohs))
+}
+
 func TestParseStringWeekdayToTimeWeekday(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -0,0 +1,217 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// osmWeekdays are the abbreviations of the weekdays in the OpenStreetMap opening_hours syntax,
+// monday first.
+var osmWeekdays = [...]string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+
+// ParseOSMOpeningHours parses a subset of the OpenStreetMap opening_hours syntax, as also used by
+// Overture Maps, eg. "Mo-Fr 08:00-12:00,13:00-18:00; Sa 10:00-14:00; Su off".
+//
+// The supported rules are "24/7" and rules made of a weekday selector, a time selector or both:
+//   - a weekday selector is a comma-separated list of weekdays and weekday ranges, eg. "Mo,We-Fr"
+//     or "Fr-Mo". Rules without weekday selector apply on every day;
+//   - a time selector is a comma-separated list of time ranges, eg. "08:00-12:00,13:00-18:00", or
+//     "off" or "closed". Ranges ending at or before their start run overnight into the next day,
+//     eg. "22:00-02:00". Rules without time selector are open all day.
+//
+// As in OpenStreetMap, a rule replaces the times given by earlier rules for the same weekdays, so
+// that "Mo-Fr 08:00-18:00; We 08:00-12:00" closes at noon on wednesday. Ranges running overnight
+// belong to the day on which they start. Other parts of the syntax, such as public holidays,
+// months or additional rules, are rejected.
+func ParseOSMOpeningHours(v string) ([]OpeningHours, error) {
+	var days [7][]dayPeriod
+	for _, rule := range strings.Split(v, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		if rule == "24/7" {
+			for weekday := range days {
+				days[weekday] = []dayPeriod{{start: 0, end: day}}
+			}
+			continue
+		}
+
+		weekdays, periods, err := parseOSMRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid opening_hours rule `%s`: %s", rule, err)
+		}
+		for _, weekday := range weekdays {
+			days[weekday] = periods
+		}
+	}
+
+	var intervals []weekInterval
+	for weekday, periods := range days {
+		midnight := time.Duration(weekday) * day
+		for _, p := range periods {
+			intervals = append(intervals, weekInterval{start: midnight + p.start, end: midnight + p.end})
+		}
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
+
+// parseOSMRule returns the weekdays, monday being 0, and the periods of a rule. The periods may end
+// after midnight when running overnight.
+func parseOSMRule(rule string) ([]int, []dayPeriod, error) {
+	weekdays := []int{0, 1, 2, 3, 4, 5, 6}
+	selector, times, _ := strings.Cut(rule, " ")
+	if len(selector) >= 2 && slices.Contains(osmWeekdays[:], selector[:2]) {
+		var err error
+		weekdays, err = parseOSMWeekdays(selector)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		times = rule
+	}
+
+	times = strings.TrimSpace(times)
+	switch times {
+	case "":
+		return weekdays, []dayPeriod{{start: 0, end: day}}, nil
+	case "off", "closed":
+		return weekdays, nil, nil
+	}
+
+	var periods []dayPeriod
+	for _, r := range strings.Split(times, ",") {
+		r = strings.TrimSpace(r)
+		from, to, ok := strings.Cut(r, "-")
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported time range `%s`: expected to be formatted as hh:mm-hh:mm", r)
+		}
+
+		start, err := parseClockTime(from)
+		if err != nil {
+			return nil, nil, err
+		}
+		if start == 1440 {
+			return nil, nil, fmt.Errorf("invalid time range `%s`: expected to start before 24:00", r)
+		}
+		end, err := parseClockTime(to)
+		if err != nil {
+			return nil, nil, err
+		}
+		if end <= start {
+			end += 1440
+		}
+
+		periods = append(periods, dayPeriod{start: time.Duration(start) * time.Minute, end: time.Duration(end) * time.Minute})
+	}
+
+	return weekdays, periods, nil
+}
+
+// parseOSMWeekdays returns the weekdays, monday being 0, of a weekday selector such as "Mo,We-Fr".
+func parseOSMWeekdays(selector string) ([]int, error) {
+	var weekdays []int
+	for _, item := range strings.Split(selector, ",") {
+		from, to, isRange := strings.Cut(item, "-")
+		first := slices.Index(osmWeekdays[:], from)
+		if first < 0 {
+			return nil, fmt.Errorf("unsupported weekday `%s`: expected to be Mo, Tu, We, Th, Fr, Sa or Su", from)
+		}
+		last := first
+		if isRange {
+			last = slices.Index(osmWeekdays[:], to)
+			if last < 0 {
+				return nil, fmt.Errorf("unsupported weekday `%s`: expected to be Mo, Tu, We, Th, Fr, Sa or Su", to)
+			}
+		}
+
+		for weekday := first; ; weekday = (weekday + 1) % 7 {
+			weekdays = append(weekdays, weekday)
+			if weekday == last {
+				break
+			}
+		}
+	}
+
+	return weekdays, nil
+}
+
+// GetOSMOpeningHours returns the opening hours in the OpenStreetMap opening_hours syntax, eg.
+// "Mo-Fr 08:00-18:00; Sa 10:00-14:00". Ranges running past midnight are split at midnight, and
+// days with the same times are grouped into a single rule. Opening hours that are always open are
+// "24/7" and opening hours that are never open are "off".
+//
+// Like GetOCPIOpeningTimes, opening times are rounded down and closing times are rounded up to the
+// minute. An error is returned when any of the opening hours is invalid.
+func GetOSMOpeningHours(ohs []OpeningHours) (string, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	if isTwentyFourSeven(ohs) {
+		return "24/7", nil
+	}
+
+	var times []string
+	var weekdays [][]int
+	for weekday, periods := range dailyPeriods(ohs) {
+		if len(periods) == 0 {
+			continue
+		}
+
+		ranges := make([]string, 0, len(periods))
+		for _, p := range periods {
+			begin, end := p.minutes()
+			ranges = append(ranges, minutesSinceMidnightToTime(begin)+"-"+minutesSinceMidnightToTime(end))
+		}
+
+		t := strings.Join(ranges, ",")
+		i := slices.Index(times, t)
+		if i < 0 {
+			times = append(times, t)
+			weekdays = append(weekdays, nil)
+			i = len(times) - 1
+		}
+		weekdays[i] = append(weekdays[i], weekday)
+	}
+	if len(times) == 0 {
+		return "off", nil
+	}
+
+	rules := make([]string, 0, len(times))
+	for i, t := range times {
+		rules = append(rules, osmWeekdaySelector(weekdays[i])+" "+t)
+	}
+
+	return strings.Join(rules, "; "), nil
+}
+
+// osmWeekdaySelector returns the weekday selector of the sorted weekdays, monday being 0, joining
+// three or more consecutive days into a range, eg. "Mo-We,Fr,Sa".
+func osmWeekdaySelector(weekdays []int) string {
+	var items []string
+	for i := 0; i < len(weekdays); {
+		j := i
+		for j+1 < len(weekdays) && weekdays[j+1] == weekdays[j]+1 {
+			j++
+		}
+
+		switch {
+		case j-i >= 2:
+			items = append(items, osmWeekdays[weekdays[i]]+"-"+osmWeekdays[weekdays[j]])
+		default:
+			for k := i; k <= j; k++ {
+				items = append(items, osmWeekdays[weekdays[k]])
+			}
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
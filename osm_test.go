@@ -0,0 +1,123 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOSMOpeningHours(t *testing.T) {
+	tests := map[string]struct {
+		value          string
+		expectedResult string
+		expectedError  error
+	}{
+		"when weekdays and saturday": {
+			value:          "Mo-Fr 08:00-12:00,13:00-18:00; Sa 10:00-14:00; Su off",
+			expectedResult: "W1T08:00:00/W1T12:00:00,W1T13:00:00/W1T18:00:00,W2T08:00:00/W2T12:00:00,W2T13:00:00/W2T18:00:00,W3T08:00:00/W3T12:00:00,W3T13:00:00/W3T18:00:00,W4T08:00:00/W4T12:00:00,W4T13:00:00/W4T18:00:00,W5T08:00:00/W5T12:00:00,W5T13:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00",
+		},
+		"when 24/7": {
+			value:          "24/7",
+			expectedResult: TwentyFourSevenString,
+		},
+		"when later rule overrides": {
+			value:          "Mo-We 08:00-18:00; We 08:00-12:00; Tu closed",
+			expectedResult: "W1T08:00:00/W1T18:00:00,W3T08:00:00/W3T12:00:00",
+		},
+		"when overnight around the end of the week": {
+			value:          "Fr,Su 22:00-02:00",
+			expectedResult: "W5T22:00:00/W6T02:00:00,W7T22:00:00/W1T02:00:00",
+		},
+		"when weekday range wraps": {
+			value:          "Sa-Mo 10:00-24:00",
+			expectedResult: "W1T10:00:00/W1T24:00:00,W6T10:00:00/W6T24:00:00,W7T10:00:00/W7T24:00:00",
+		},
+		"when without weekdays": {
+			value:          "Sa,Su; 09:00-17:00",
+			expectedResult: "W1T09:00:00/W1T17:00:00,W2T09:00:00/W2T17:00:00,W3T09:00:00/W3T17:00:00,W4T09:00:00/W4T17:00:00,W5T09:00:00/W5T17:00:00,W6T09:00:00/W6T17:00:00,W7T09:00:00/W7T17:00:00",
+		},
+		"when without times": {
+			value:          "Sa-Su",
+			expectedResult: "W6T00:00:00/W7T24:00:00",
+		},
+		"when off": {
+			value:          "off",
+			expectedResult: "",
+		},
+		"when public holidays": {
+			value:         "Mo-Fr 08:00-18:00; PH off",
+			expectedError: fmt.Errorf("invalid opening_hours rule `PH off`: unsupported time range `PH off`: expected to be formatted as hh:mm-hh:mm"),
+		},
+		"when weekday invalid": {
+			value:         "Mo-Fx 08:00-18:00",
+			expectedError: fmt.Errorf("invalid opening_hours rule `Mo-Fx 08:00-18:00`: unsupported weekday `Fx`: expected to be Mo, Tu, We, Th, Fr, Sa or Su"),
+		},
+		"when time invalid": {
+			value:         "Mo 8:00-18:00",
+			expectedError: fmt.Errorf("invalid opening_hours rule `Mo 8:00-18:00`: invalid time `8:00`: expected to be formatted as hh:mm"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseOSMOpeningHours(tt.value)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestGetOSMOpeningHours(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult string
+		expectedError  error
+	}{
+		"when weekdays and saturday": {
+			openingHours:   mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W2T08:00:00/W2T18:00:00,W3T08:00:00/W3T18:00:00,W5T08:00:00/W5T18:00:00,W6T10:00:00/W6T14:00:00"),
+			expectedResult: "Mo-We,Fr 08:00-18:00; Sa 10:00-14:00",
+		},
+		"when two days": {
+			openingHours:   mustParseOpeningHours("W6T10:00:00/W6T14:00:00,W7T10:00:00/W7T14:00:00"),
+			expectedResult: "Sa,Su 10:00-14:00",
+		},
+		"when overnight": {
+			openingHours:   mustParseOpeningHours("W5T22:00:00/W6T02:00:00"),
+			expectedResult: "Fr 22:00-24:00; Sa 00:00-02:00",
+		},
+		"when 24/7": {
+			openingHours:   []OpeningHours{TwentyFourSevenOH},
+			expectedResult: "24/7",
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: "off",
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetOSMOpeningHours(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
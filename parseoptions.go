@@ -0,0 +1,189 @@
+package openinghours
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ParseOptions configures how ParseOpeningHoursWithOptions parses opening hours strings. The zero
+// value is the strictest configuration, except for RequireSortedNonOverlapping.
+type ParseOptions struct {
+	// Separators are the characters that may separate two ranges. Defaults to "," when empty.
+	Separators string
+
+	// AllowEmpty allows empty ranges, eg. in "W1T08:00:00/W1T16:00:00,", which are skipped.
+	AllowEmpty bool
+
+	// AllowOpenEnded allows ranges without opening or closing time, eg. "/W1T16:00:00". See
+	// OpeningHours for their meaning.
+	AllowOpenEnded bool
+
+	// AcceptSeconds allows times whose seconds are not 00, eg. "W1T08:00:30".
+	AcceptSeconds bool
+
+	// AllowShortTimes allows times without seconds and hours without leading zero, eg. "W1T8:00".
+	AllowShortTimes bool
+
+	// IgnoreCase allows lowercase week and time designators, eg. "w1t08:00:00".
+	IgnoreCase bool
+
+	// AllowWhitespace allows whitespace around ranges and around opening and closing times.
+	AllowWhitespace bool
+
+	// RequireSortedNonOverlapping requires ranges to be sorted by opening time and not to overlap
+	// each other, including ranges that wrap around the end of the week.
+	RequireSortedNonOverlapping bool
+}
+
+var (
+	// DefaultParseOptions are the options used by ParseOpeningHours.
+	DefaultParseOptions = ParseOptions{
+		AllowEmpty:     true,
+		AllowOpenEnded: true,
+		AcceptSeconds:  true,
+	}
+
+	// StrictParseOptions only accept the exact format produced by OpeningHoursSliceToString, with
+	// complete, sorted and non-overlapping ranges.
+	StrictParseOptions = ParseOptions{
+		RequireSortedNonOverlapping: true,
+	}
+
+	// LenientParseOptions accept anything that can reasonably be understood as opening hours, eg.
+	// "w1t8:00 / w1t16:00; W2T08:00:00/W2T16:00:00".
+	LenientParseOptions = ParseOptions{
+		Separators:      ",;",
+		AllowEmpty:      true,
+		AllowOpenEnded:  true,
+		AcceptSeconds:   true,
+		AllowShortTimes: true,
+		IgnoreCase:      true,
+		AllowWhitespace: true,
+	}
+)
+
+// timeInWeekRegexps holds the regular expressions matching a time in the week, indexed by
+// AllowShortTimes and IgnoreCase.
+var timeInWeekRegexps = map[[2]bool]*regexp.Regexp{
+	{false, false}: regexp.MustCompile(`^W(\d)T(\d{2}):(\d{2}):(\d{2})$`),
+	{false, true}:  regexp.MustCompile(`(?i)^W(\d)T(\d{2}):(\d{2}):(\d{2})$`),
+	{true, false}:  regexp.MustCompile(`^W(\d)T(\d{1,2}):(\d{2})(?::(\d{2}))?$`),
+	{true, true}:   regexp.MustCompile(`(?i)^W(\d)T(\d{1,2}):(\d{2})(?::(\d{2}))?$`),
+}
+
+func (opts ParseOptions) timeInWeekRegexp() *regexp.Regexp {
+	return timeInWeekRegexps[[2]bool{opts.AllowShortTimes, opts.IgnoreCase}]
+}
+
+// ParseOpeningHoursWithOptions converts a string like "W1T08:00:00/W1T20:00:00" into a
+// []OpeningHours, like ParseOpeningHours, but with the given options.
+func ParseOpeningHoursWithOptions(v string, opts ParseOptions) ([]OpeningHours, error) {
+	separators := opts.Separators
+	if separators == "" {
+		separators = ","
+	}
+
+	if opts.AllowWhitespace {
+		v = strings.TrimSpace(v)
+	}
+	if v == "" {
+		return []OpeningHours{}, nil
+	}
+
+	strs := splitAny(v, separators)
+
+	ohs := make([]OpeningHours, 0, len(strs))
+	for _, str := range strs {
+		if opts.AllowWhitespace {
+			str = strings.TrimSpace(str)
+		}
+		if str == "" {
+			if !opts.AllowEmpty {
+				return nil, fmt.Errorf("invalid opening hours string `%s`: empty range", v)
+			}
+			continue
+		}
+
+		parts := strings.Split(str, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid opening hours string `%s`", str)
+		}
+
+		openingHours, err := parseTimeInWeek(parts[0], opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid opening hours: %s", err)
+		}
+
+		closingHours, err := parseTimeInWeek(parts[1], opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid closing hours: %s", err)
+		}
+
+		if !opts.AllowOpenEnded && (openingHours == nil || closingHours == nil) {
+			return nil, fmt.Errorf("invalid opening hours string `%s`: expected both opening and closing hours", str)
+		}
+
+		oh := OpeningHours{
+			Open:  openingHours,
+			Close: closingHours,
+		}
+
+		ohs = append(ohs, oh)
+	}
+
+	if opts.RequireSortedNonOverlapping {
+		if err := checkSortedNonOverlapping(ohs); err != nil {
+			return nil, err
+		}
+	}
+
+	return ohs, nil
+}
+
+func splitAny(v string, separators string) []string {
+	var strs []string
+	for {
+		i := strings.IndexAny(v, separators)
+		if i < 0 {
+			return append(strs, v)
+		}
+		strs = append(strs, v[:i])
+		v = v[i+1:]
+	}
+}
+
+// checkSortedNonOverlapping returns an error when the opening hours are not sorted by opening time
+// or when any two of them overlap.
+func checkSortedNonOverlapping(ohs []OpeningHours) error {
+	type parsedRange struct {
+		oh OpeningHours
+		wi weekInterval
+	}
+
+	intervals := make([]parsedRange, 0, len(ohs))
+	for _, oh := range ohs {
+		if wi, ok := oh.interval(); ok {
+			intervals = append(intervals, parsedRange{oh: oh, wi: wi})
+		}
+	}
+
+	for i := 1; i < len(intervals); i++ {
+		prev, cur := intervals[i-1], intervals[i]
+		if cur.wi.start < prev.wi.start {
+			return fmt.Errorf("opening hours `%s` not sorted: expected to be after `%s`", prev.oh, cur.oh)
+		}
+		if cur.wi.start < prev.wi.end {
+			return fmt.Errorf("opening hours `%s` overlap `%s`", prev.oh, cur.oh)
+		}
+	}
+
+	if n := len(intervals); n > 1 {
+		last, first := intervals[n-1], intervals[0]
+		if last.wi.end-week > first.wi.start {
+			return fmt.Errorf("opening hours `%s` overlap `%s`", last.oh, first.oh)
+		}
+	}
+
+	return nil
+}
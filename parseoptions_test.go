@@ -0,0 +1,135 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOpeningHoursWithOptions(t *testing.T) {
+	mondayEightToFour := []OpeningHours{{
+		Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+		Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+	}}
+
+	tests := map[string]struct {
+		openingHours   string
+		opts           ParseOptions
+		expectedResult []OpeningHours
+		expectedError  error
+	}{
+		"when default skips empty ranges": {
+			openingHours:   ",W1T08:00:00/W1T16:00:00,",
+			opts:           DefaultParseOptions,
+			expectedResult: mondayEightToFour,
+		},
+		"when default keeps seconds": {
+			openingHours: "W1T08:00:30/W1T16:00:00",
+			opts:         DefaultParseOptions,
+			expectedResult: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480, Seconds: 30},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+		},
+		"when strict with valid string": {
+			openingHours: "W1T08:00:00/W1T16:00:00,W2T06:00:00/W2T20:00:00,W7T22:00:00/W1T06:00:00",
+			opts:         StrictParseOptions,
+			expectedResult: []OpeningHours{
+				{
+					Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+					Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+				},
+				{
+					Open:  &TimeInWeek{Weekday: 2, MinutesSinceMidnight: 360},
+					Close: &TimeInWeek{Weekday: 2, MinutesSinceMidnight: 1200},
+				},
+				{
+					Open:  &TimeInWeek{Weekday: 7, MinutesSinceMidnight: 1320},
+					Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 360},
+				},
+			},
+		},
+		"when strict with empty string": {
+			openingHours:   "",
+			opts:           StrictParseOptions,
+			expectedResult: []OpeningHours{},
+		},
+		"when strict with empty range": {
+			openingHours:  "W1T08:00:00/W1T16:00:00,",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("invalid opening hours string `W1T08:00:00/W1T16:00:00,`: empty range"),
+		},
+		"when strict with open-ended range": {
+			openingHours:  "/W1T16:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("invalid opening hours string `/W1T16:00:00`: expected both opening and closing hours"),
+		},
+		"when strict with seconds": {
+			openingHours:  "W1T08:00:30/W1T16:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("invalid opening hours: invalid seconds in `W1T08:00:30`: expected to be 00"),
+		},
+		"when strict with unsorted ranges": {
+			openingHours:  "W2T08:00:00/W2T16:00:00,W1T08:00:00/W1T16:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("opening hours `W2T08:00:00/W2T16:00:00` not sorted: expected to be after `W1T08:00:00/W1T16:00:00`"),
+		},
+		"when strict with overlapping ranges": {
+			openingHours:  "W1T08:00:00/W1T16:00:00,W1T12:00:00/W1T18:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("opening hours `W1T08:00:00/W1T16:00:00` overlap `W1T12:00:00/W1T18:00:00`"),
+		},
+		"when strict with ranges overlapping around the end of the week": {
+			openingHours:  "W1T08:00:00/W1T16:00:00,W7T22:00:00/W1T09:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("opening hours `W7T22:00:00/W1T09:00:00` overlap `W1T08:00:00/W1T16:00:00`"),
+		},
+		"when strict with lowercase": {
+			openingHours:  "w1t08:00:00/W1T16:00:00",
+			opts:          StrictParseOptions,
+			expectedError: fmt.Errorf("invalid opening hours: invalid value `w1t08:00:00`"),
+		},
+		"when lenient with lowercase and short times": {
+			openingHours:   "w1t8:00/w1t16:00",
+			opts:           LenientParseOptions,
+			expectedResult: mondayEightToFour,
+		},
+		"when lenient with whitespace and semicolons": {
+			openingHours: " W1T08:00:00 / W1T16:00:00 ; W2T06:00/W2T20:00 ;",
+			opts:         LenientParseOptions,
+			expectedResult: []OpeningHours{
+				{
+					Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+					Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+				},
+				{
+					Open:  &TimeInWeek{Weekday: 2, MinutesSinceMidnight: 360},
+					Close: &TimeInWeek{Weekday: 2, MinutesSinceMidnight: 1200},
+				},
+			},
+		},
+		"when lenient with invalid time": {
+			openingHours:  "w1t8/w1t16:00",
+			opts:          LenientParseOptions,
+			expectedError: fmt.Errorf("invalid opening hours: invalid value `w1t8`"),
+		},
+		"when only sorting required": {
+			openingHours:   "W1T08:00:00/W1T16:00:00",
+			opts:           ParseOptions{RequireSortedNonOverlapping: true, AllowOpenEnded: true},
+			expectedResult: mondayEightToFour,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseOpeningHoursWithOptions(tt.openingHours, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
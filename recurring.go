@@ -0,0 +1,101 @@
+package openinghours
+
+import (
+	"fmt"
+	"time"
+)
+
+// systemdWeekdays are the abbreviations of the weekdays in systemd calendar events, monday first.
+var systemdWeekdays = [...]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// RecurringRange holds the specifications of the recurring opening and closing times of a range of
+// opening hours.
+type RecurringRange struct {
+	Open  string
+	Close string
+}
+
+// GetSystemdOnCalendar returns, for each range of the opening hours, the systemd calendar events
+// triggering at its opening and closing times, to be used as OnCalendar= of a timer unit, eg.
+// "Mon *-*-* 08:00:00 Europe/Amsterdam". Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00,W7T22:00:00/W1T02:00:00")
+//	ranges, _ := GetSystemdOnCalendar(ohs, nil)
+//	// ranges will be:
+//	[]RecurringRange{
+//	    {Open: "Mon *-*-* 08:00:00", Close: "Mon *-*-* 18:00:00"},
+//	    {Open: "Sun *-*-* 22:00:00", Close: "Mon *-*-* 02:00:00"},
+//	}
+//
+// The name of the location is appended to the calendar events, unless it is nil in which case
+// systemd uses the local time zone of the device. Closing times at midnight at the end of a day
+// trigger at 00:00:00 on the next day. Ranges that are empty are left out, and an error is
+// returned when any of the opening hours is invalid.
+func GetSystemdOnCalendar(ohs []OpeningHours, loc *time.Location) ([]RecurringRange, error) {
+	suffix := ""
+	if loc != nil {
+		suffix = " " + loc.String()
+	}
+	event := func(offset time.Duration) string {
+		offset %= week
+		return systemdWeekdays[offset/day] + " *-*-* " + timeOfDay(offset%day) + suffix
+	}
+
+	var ranges []RecurringRange
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+		wi, ok := oh.interval()
+		if !ok {
+			continue
+		}
+
+		ranges = append(ranges, RecurringRange{Open: event(wi.start), Close: event(wi.end)})
+	}
+
+	return ranges, nil
+}
+
+// GetISO8601RepeatingIntervals returns, for each range of the opening hours, the ISO 8601
+// repeating intervals starting at its opening and closing times in the week containing t, and
+// repeating every week without end, eg. "R/2026-10-19T08:00:00+02:00/P1W". Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T08:00:00/W1T18:00:00")
+//	ranges, _ := GetISO8601RepeatingIntervals(ohs, time.Date(2026, 10, 21, 0, 0, 0, 0, amsterdam), amsterdam)
+//	// ranges will be:
+//	[]RecurringRange{
+//	    {Open: "R/2026-10-19T08:00:00+02:00/P1W", Close: "R/2026-10-19T18:00:00+02:00/P1W"},
+//	}
+//
+// ISO 8601 has no way to express a time within any week, hence the anchoring at a given week. The
+// opening and closing times are mapped onto instants in the given location as described in
+// Occurrences, and closing times of ranges wrapping around the end of the week fall in the next
+// week. As P1W is a nominal week, the repetitions follow the wall clock across daylight saving time
+// changes. Ranges that are empty are left out, and an error is returned when the location is
+// missing or any of the opening hours is invalid.
+func GetISO8601RepeatingIntervals(ohs []OpeningHours, t time.Time, loc *time.Location) ([]RecurringRange, error) {
+	if loc == nil {
+		return nil, fmt.Errorf("missing time zone")
+	}
+
+	monday := startOfWeek(t, loc)
+	interval := func(offset time.Duration) string {
+		return "R/" + inLocation(monday.Add(offset), loc).Format(time.RFC3339) + "/P1W"
+	}
+
+	var ranges []RecurringRange
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+		wi, ok := oh.interval()
+		if !ok {
+			continue
+		}
+
+		ranges = append(ranges, RecurringRange{Open: interval(wi.start), Close: interval(wi.end)})
+	}
+
+	return ranges, nil
+}
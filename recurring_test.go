@@ -0,0 +1,126 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSystemdOnCalendar(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		loc            *time.Location
+		expectedResult []RecurringRange
+		expectedError  error
+	}{
+		"when regular and overnight ranges": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W7T22:00:00/W1T02:00:00"),
+			expectedResult: []RecurringRange{
+				{Open: "Mon *-*-* 08:00:00", Close: "Mon *-*-* 18:00:00"},
+				{Open: "Sun *-*-* 22:00:00", Close: "Mon *-*-* 02:00:00"},
+			},
+		},
+		"when until midnight with time zone": {
+			openingHours: mustParseOpeningHours("W5T17:30:15/W5T24:00:00"),
+			loc:          mustLoadLocation("Europe/Amsterdam"),
+			expectedResult: []RecurringRange{
+				{Open: "Fri *-*-* 17:30:15 Europe/Amsterdam", Close: "Sat *-*-* 00:00:00 Europe/Amsterdam"},
+			},
+		},
+		"when open-ended": {
+			openingHours: mustParseOpeningHours("/W1T16:00:00"),
+			loc:          time.UTC,
+			expectedResult: []RecurringRange{
+				{Open: "Mon *-*-* 00:00:00 UTC", Close: "Mon *-*-* 16:00:00 UTC"},
+			},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: nil,
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetSystemdOnCalendar(tt.openingHours, tt.loc)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGetISO8601RepeatingIntervals(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		t              time.Time
+		loc            *time.Location
+		expectedResult []RecurringRange
+		expectedError  error
+	}{
+		"when regular and overnight ranges": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W7T22:00:00/W1T02:00:00"),
+			t:            time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC),
+			loc:          time.UTC,
+			expectedResult: []RecurringRange{
+				{Open: "R/2026-10-19T08:00:00Z/P1W", Close: "R/2026-10-19T18:00:00Z/P1W"},
+				{Open: "R/2026-10-25T22:00:00Z/P1W", Close: "R/2026-10-26T02:00:00Z/P1W"},
+			},
+		},
+		"when clocks go back during the week": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00,W7T08:00:00/W7T18:00:00"),
+			t:            time.Date(2026, 10, 21, 12, 0, 0, 0, amsterdam),
+			loc:          amsterdam,
+			expectedResult: []RecurringRange{
+				{Open: "R/2026-10-19T08:00:00+02:00/P1W", Close: "R/2026-10-19T18:00:00+02:00/P1W"},
+				{Open: "R/2026-10-25T08:00:00+01:00/P1W", Close: "R/2026-10-25T18:00:00+01:00/P1W"},
+			},
+		},
+		"when anchored on a sunday in another time zone": {
+			openingHours: mustParseOpeningHours("W1T08:00:00/W1T18:00:00"),
+			t:            time.Date(2026, 10, 25, 23, 30, 0, 0, time.UTC),
+			loc:          amsterdam,
+			expectedResult: []RecurringRange{
+				{Open: "R/2026-10-26T08:00:00+01:00/P1W", Close: "R/2026-10-26T18:00:00+01:00/P1W"},
+			},
+		},
+		"when time zone missing": {
+			openingHours:  mustParseOpeningHours("W1T08:00:00/W1T18:00:00"),
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			loc:           time.UTC,
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetISO8601RepeatingIntervals(tt.openingHours, tt.t, tt.loc)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
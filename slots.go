@@ -0,0 +1,86 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SlotOptions configures the slots generated by Slots.
+type SlotOptions struct {
+	// Length is the duration of each slot.
+	Length time.Duration
+
+	// Step is the time between the start of two consecutive slots. Defaults to Length when zero.
+	Step time.Duration
+
+	// BufferBeforeClose is the time before closing during which no slot may take place.
+	BufferBeforeClose time.Duration
+
+	// Bookings are the slots already taken. Slots overlapping any of them are left out.
+	Bookings []Interval
+}
+
+// Slots returns the bookable slots between from and to, with the opening hours being interpreted
+// in the given location.
+//
+// Slots start at opening time and follow each other every opts.Step, those starting before from
+// being left out: when from falls in the middle of a slot, the first slot starts at the next step.
+// Opening hours that never close, such as 24/7, are aligned on the Monday midnight of the week
+// before from. A slot never straddles a closure, but it may straddle midnight when the opening
+// hours run overnight. See Occurrences for how the opening hours are mapped onto actual instants.
+//
+// An error is returned when the time zone is missing, the length is not positive, or the step or
+// the buffer before close is negative.
+func Slots(ohs []OpeningHours, from, to time.Time, loc *time.Location, opts SlotOptions) ([]Interval, error) {
+	if loc == nil {
+		return nil, fmt.Errorf("missing time zone")
+	}
+	if opts.Length <= 0 {
+		return nil, fmt.Errorf("invalid slot length `%s`: expected to be positive", opts.Length)
+	}
+	if opts.Step < 0 {
+		return nil, fmt.Errorf("invalid slot step `%s`: expected to be non-negative", opts.Step)
+	}
+	if opts.BufferBeforeClose < 0 {
+		return nil, fmt.Errorf("invalid buffer before close `%s`: expected to be non-negative", opts.BufferBeforeClose)
+	}
+
+	step := opts.Step
+	if step == 0 {
+		step = opts.Length
+	}
+
+	var slots []Interval
+	// The occurrences are looked up around from and to, so that slots are aligned on the actual
+	// opening time and the buffer is taken off the actual closing time.
+	lookupFrom := inLocation(startOfWeek(from, loc).AddDate(0, 0, -7), loc)
+	for period := range Occurrences(ohs, lookupFrom, to.Add(week), loc) {
+		if !period.End.After(from) {
+			continue
+		}
+		if !period.Start.Before(to) {
+			break
+		}
+
+		start := period.Start
+		if start.Before(from) {
+			start = start.Add((from.Sub(start) + step - 1) / step * step)
+		}
+		end := period.End.Add(-opts.BufferBeforeClose)
+		for ; !start.Add(opts.Length).After(end) && !start.Add(opts.Length).After(to); start = start.Add(step) {
+			slot := Interval{Start: start, End: start.Add(opts.Length)}
+			if slices.ContainsFunc(opts.Bookings, slot.overlaps) {
+				continue
+			}
+			slots = append(slots, slot)
+		}
+	}
+
+	return slots, nil
+}
+
+// overlaps reports whether both intervals have some time in common.
+func (i Interval) overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
@@ -0,0 +1,206 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlots(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	slot := func(day, hour, minute int, length time.Duration) Interval {
+		return Interval{Start: at(day, hour, minute), End: at(day, hour, minute).Add(length)}
+	}
+
+	tests := map[string]struct {
+		openingHours   string
+		from           time.Time
+		to             time.Time
+		loc            *time.Location
+		opts           SlotOptions
+		expectedResult []Interval
+		expectedError  error
+	}{
+		"when slots fill the opening hours": {
+			openingHours: "W1T08:00:00/W1T10:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 30 * time.Minute},
+			expectedResult: []Interval{
+				slot(19, 8, 0, 30*time.Minute),
+				slot(19, 8, 30, 30*time.Minute),
+				slot(19, 9, 0, 30*time.Minute),
+				slot(19, 9, 30, 30*time.Minute),
+			},
+		},
+		"when step is shorter than length": {
+			openingHours: "W1T08:00:00/W1T09:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 30 * time.Minute, Step: 15 * time.Minute},
+			expectedResult: []Interval{
+				slot(19, 8, 0, 30*time.Minute),
+				slot(19, 8, 15, 30*time.Minute),
+				slot(19, 8, 30, 30*time.Minute),
+			},
+		},
+		"when buffer before close": {
+			openingHours: "W1T08:00:00/W1T10:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 30 * time.Minute, BufferBeforeClose: 45 * time.Minute},
+			expectedResult: []Interval{
+				slot(19, 8, 0, 30*time.Minute),
+				slot(19, 8, 30, 30*time.Minute),
+			},
+		},
+		"when buffer before close and to during opening hours": {
+			openingHours: "W1T08:00:00/W1T18:00:00",
+			from:         at(19, 8, 0),
+			to:           at(19, 12, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: time.Hour, BufferBeforeClose: time.Hour},
+			expectedResult: []Interval{
+				slot(19, 8, 0, time.Hour),
+				slot(19, 9, 0, time.Hour),
+				slot(19, 10, 0, time.Hour),
+				slot(19, 11, 0, time.Hour),
+			},
+		},
+		"when from in the middle of a slot": {
+			openingHours: "W1T08:00:00/W1T10:00:00",
+			from:         at(19, 9, 7).Add(31 * time.Second),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 30 * time.Minute},
+			expectedResult: []Interval{
+				slot(19, 9, 30, 30*time.Minute),
+			},
+		},
+		"when from after opening on the previous day": {
+			openingHours: "W7T22:00:00/W1T02:00:00",
+			from:         at(19, 0, 10),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: time.Hour},
+			expectedResult: []Interval{
+				slot(19, 1, 0, time.Hour),
+				slot(25, 22, 0, time.Hour),
+				slot(25, 23, 0, time.Hour),
+			},
+		},
+		"when overnight range": {
+			openingHours: "W1T22:00:00/W2T02:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 90 * time.Minute, Step: time.Hour},
+			expectedResult: []Interval{
+				slot(19, 22, 0, 90*time.Minute),
+				slot(19, 23, 0, 90*time.Minute),
+				slot(20, 0, 0, 90*time.Minute),
+			},
+		},
+		"when closed in between": {
+			openingHours: "W1T08:00:00/W1T09:15:00,W1T10:00:00/W1T11:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: 30 * time.Minute},
+			expectedResult: []Interval{
+				slot(19, 8, 0, 30*time.Minute),
+				slot(19, 8, 30, 30*time.Minute),
+				slot(19, 10, 0, 30*time.Minute),
+				slot(19, 10, 30, 30*time.Minute),
+			},
+		},
+		"when slots already booked": {
+			openingHours: "W1T08:00:00/W1T10:00:00",
+			from:         at(19, 0, 0),
+			to:           at(26, 0, 0),
+			loc:          time.UTC,
+			opts: SlotOptions{
+				Length: 30 * time.Minute,
+				Bookings: []Interval{
+					{Start: at(19, 8, 15), End: at(19, 8, 45)},
+					{Start: at(19, 9, 30), End: at(19, 10, 0)},
+				},
+			},
+			expectedResult: []Interval{
+				slot(19, 9, 0, 30*time.Minute),
+			},
+		},
+		"when several weeks": {
+			openingHours: "W3T12:00:00/W3T13:00:00",
+			from:         at(19, 0, 0),
+			to:           at(31, 0, 0),
+			loc:          time.UTC,
+			opts:         SlotOptions{Length: time.Hour},
+			expectedResult: []Interval{
+				slot(21, 12, 0, time.Hour),
+				slot(28, 12, 0, time.Hour),
+			},
+		},
+		"when never open": {
+			openingHours:   "",
+			from:           at(19, 0, 0),
+			to:             at(26, 0, 0),
+			loc:            time.UTC,
+			opts:           SlotOptions{Length: time.Hour},
+			expectedResult: nil,
+		},
+		"when time zone missing": {
+			openingHours:  "W1T08:00:00/W1T10:00:00",
+			from:          at(19, 0, 0),
+			to:            at(26, 0, 0),
+			opts:          SlotOptions{Length: time.Hour},
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+		"when length invalid": {
+			openingHours:  "W1T08:00:00/W1T10:00:00",
+			from:          at(19, 0, 0),
+			to:            at(26, 0, 0),
+			loc:           time.UTC,
+			opts:          SlotOptions{},
+			expectedError: fmt.Errorf("invalid slot length `0s`: expected to be positive"),
+		},
+		"when step invalid": {
+			openingHours:  "W1T08:00:00/W1T10:00:00",
+			from:          at(19, 0, 0),
+			to:            at(26, 0, 0),
+			loc:           time.UTC,
+			opts:          SlotOptions{Length: time.Hour, Step: -time.Hour},
+			expectedError: fmt.Errorf("invalid slot step `-1h0m0s`: expected to be non-negative"),
+		},
+		"when buffer invalid": {
+			openingHours:  "W1T08:00:00/W1T10:00:00",
+			from:          at(19, 0, 0),
+			to:            at(26, 0, 0),
+			loc:           time.UTC,
+			opts:          SlotOptions{Length: time.Hour, BufferBeforeClose: -time.Hour},
+			expectedError: fmt.Errorf("invalid buffer before close `-1h0m0s`: expected to be non-negative"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, err := Slots(ohs, tt.from, tt.to, tt.loc, tt.opts)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package openinghours
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is the state of opening hours at a given time, see Status.
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpeningSoon
+	StateOpen
+	StateClosingSoon
+	StateAlwaysOpen
+)
+
+// String returns the name of the state, eg. "closing soon".
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpeningSoon:
+		return "opening soon"
+	case StateOpen:
+		return "open"
+	case StateClosingSoon:
+		return "closing soon"
+	case StateAlwaysOpen:
+		return "always open"
+	default:
+		return ""
+	}
+}
+
+// Thresholds configures how long before opening or closing time the opening hours are considered
+// to be opening or closing soon. A zero threshold disables the corresponding state.
+type Thresholds struct {
+	OpeningSoon time.Duration
+	ClosingSoon time.Duration
+}
+
+// OpenStatus describes the state of opening hours at a given time.
+type OpenStatus struct {
+	State State
+
+	// Next is the time of the next transition, ie. the closing time when open and the opening time
+	// when closed. It is zero when always open or never open.
+	Next time.Time
+
+	// Remaining is the time left until Next.
+	Remaining time.Duration
+}
+
+// Status returns the state of the opening hours at t, with the opening hours being interpreted in
+// the given location. See Occurrences for how the opening hours are mapped onto actual instants.
+// An error is returned when the time zone is missing.
+func Status(ohs []OpeningHours, t time.Time, loc *time.Location, thresholds Thresholds) (OpenStatus, error) {
+	if loc == nil {
+		return OpenStatus{}, fmt.Errorf("missing time zone")
+	}
+
+	intervals := weekIntervals(ohs)
+	if len(intervals) == 1 && intervals[0] == (weekInterval{start: 0, end: week}) {
+		return OpenStatus{State: StateAlwaysOpen}, nil
+	}
+
+	// Unless always open, the opening hours close at least once a week. The extra day makes up for
+	// the clocks changing.
+	for period := range Occurrences(ohs, t, t.Add(week+day), loc) {
+		if period.Start.After(t) {
+			status := OpenStatus{State: StateClosed, Next: period.Start, Remaining: period.Start.Sub(t)}
+			if status.Remaining <= thresholds.OpeningSoon {
+				status.State = StateOpeningSoon
+			}
+			return status, nil
+		}
+
+		status := OpenStatus{State: StateOpen, Next: period.End, Remaining: period.End.Sub(t)}
+		if status.Remaining <= thresholds.ClosingSoon {
+			status.State = StateClosingSoon
+		}
+		return status, nil
+	}
+
+	return OpenStatus{State: StateClosed}, nil
+}
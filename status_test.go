@@ -0,0 +1,138 @@
+package openinghours
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	thresholds := Thresholds{OpeningSoon: time.Hour, ClosingSoon: 30 * time.Minute}
+
+	tests := map[string]struct {
+		openingHours   string
+		t              time.Time
+		loc            *time.Location
+		expectedResult OpenStatus
+		expectedError  error
+	}{
+		"when open": {
+			openingHours:   "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00",
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpen, Next: at(19, 16, 0), Remaining: 6 * time.Hour},
+		},
+		"when closing soon": {
+			openingHours:   "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00",
+			t:              at(19, 15, 45),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateClosingSoon, Next: at(19, 16, 0), Remaining: 15 * time.Minute},
+		},
+		"when closed": {
+			openingHours:   "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00",
+			t:              at(19, 16, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateClosed, Next: at(20, 8, 0), Remaining: 16 * time.Hour},
+		},
+		"when opening soon": {
+			openingHours:   "W1T08:00:00/W1T16:00:00,W2T08:00:00/W2T16:00:00",
+			t:              at(19, 7, 30),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpeningSoon, Next: at(19, 8, 0), Remaining: 30 * time.Minute},
+		},
+		"when closed until next week": {
+			openingHours:   "W1T08:00:00/W1T16:00:00",
+			t:              at(19, 17, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateClosed, Next: at(26, 8, 0), Remaining: 6*24*time.Hour + 15*time.Hour},
+		},
+		"when overnight": {
+			openingHours:   "W1T22:00:00/W2T06:00:00",
+			t:              at(20, 2, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpen, Next: at(20, 6, 0), Remaining: 4 * time.Hour},
+		},
+		"when open across the end of the week": {
+			openingHours:   "W7T22:00:00/W1T06:00:00",
+			t:              at(25, 23, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpen, Next: at(26, 6, 0), Remaining: 7 * time.Hour},
+		},
+		"when 24/7": {
+			openingHours:   TwentyFourSevenString,
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateAlwaysOpen},
+		},
+		"when 24/7 in several ranges": {
+			openingHours:   "W1T00:00:00/W4T12:00:00,W4T12:00:00/W1T00:00:00",
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateAlwaysOpen},
+		},
+		"when opening time not specified": {
+			openingHours:   "/W1T16:00:00",
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpen, Next: at(19, 16, 0), Remaining: 6 * time.Hour},
+		},
+		"when closing time not specified": {
+			openingHours:   "W7T08:00:00/",
+			t:              at(25, 20, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateOpen, Next: at(26, 0, 0), Remaining: 4 * time.Hour},
+		},
+		"when opening and closing times not specified": {
+			openingHours:   "/",
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateClosed},
+		},
+		"when never open": {
+			openingHours:   "",
+			t:              at(19, 10, 0),
+			loc:            time.UTC,
+			expectedResult: OpenStatus{State: StateClosed},
+		},
+		"when time zone missing": {
+			openingHours:  "W1T08:00:00/W1T16:00:00",
+			t:             at(19, 10, 0),
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, err := Status(ohs, tt.t, tt.loc, thresholds)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestStateString(t *testing.T) {
+	tests := map[State]string{
+		StateClosed:      "closed",
+		StateOpeningSoon: "opening soon",
+		StateOpen:        "open",
+		StateClosingSoon: "closing soon",
+		StateAlwaysOpen:  "always open",
+		State(42):        "",
+	}
+
+	for state, expectedResult := range tests {
+		assert.Equal(t, expectedResult, state.String())
+	}
+}
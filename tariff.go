@@ -0,0 +1,118 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ocpiDaysOfWeek are the values of the OCPI DayOfWeek enum, monday first.
+var ocpiDaysOfWeek = [...]string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// OCPITariffRestrictions represents the time related fields of the TariffRestrictions class from
+// the OCPI standard. The other restrictions, such as start_date or min_kwh, don't depend on the
+// time of the week.
+type OCPITariffRestrictions struct {
+	StartTime string   `json:"start_time,omitempty" example:"22:00"`
+	EndTime   string   `json:"end_time,omitempty" example:"00:00"` // "00:00" signals that the restriction applies until midnight at the end of the day.
+	DayOfWeek []string `json:"day_of_week,omitempty" example:"MONDAY"`
+}
+
+// GetOCPITariffRestrictions returns the tariff restrictions under which a tariff element applies
+// during the given opening hours, eg. during off-peak hours.
+//
+// Although OCPI allows end_time to be before start_time, it is unclear to which day of the week
+// the time after midnight belongs. Ranges running past midnight are therefore split at midnight,
+// the first part ending at 00:00 and the second part starting at 00:00 on the next day. Days
+// sharing the same start and end time are grouped into a single restriction, and full days have
+// no start and end time. Example:
+//
+//	ohs, _ := ParseOpeningHours("W1T22:00:00/W2T07:00:00,...,W5T22:00:00/W6T07:00:00")
+//	restrictions, _ := GetOCPITariffRestrictions(ohs)
+//	// restrictions will be:
+//	[]OCPITariffRestrictions{
+//	    {StartTime: "22:00", EndTime: "00:00", DayOfWeek: []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}},
+//	    {StartTime: "00:00", EndTime: "07:00", DayOfWeek: []string{"TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}},
+//	}
+//
+// Like GetOCPIOpeningTimes, start times are rounded down and end times are rounded up to the
+// minute. When the opening hours are never open, no restriction is returned: the tariff element
+// should then not be published at all. An error is returned when any of the opening hours is
+// invalid.
+func GetOCPITariffRestrictions(ohs []OpeningHours) ([]OCPITariffRestrictions, error) {
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	restrictions := []OCPITariffRestrictions{}
+	for _, rh := range normalizedOCPIRegularHours(ohs) {
+		startTime, endTime := rh.PeriodBegin, rh.PeriodEnd
+		if startTime == "00:00" && endTime == "00:00" {
+			startTime, endTime = "", ""
+		}
+
+		i := slices.IndexFunc(restrictions, func(r OCPITariffRestrictions) bool {
+			return r.StartTime == startTime && r.EndTime == endTime
+		})
+		if i < 0 {
+			restrictions = append(restrictions, OCPITariffRestrictions{StartTime: startTime, EndTime: endTime})
+			i = len(restrictions) - 1
+		}
+		restrictions[i].DayOfWeek = append(restrictions[i].DayOfWeek, ocpiDaysOfWeek[rh.Weekday-1])
+	}
+
+	return restrictions, nil
+}
+
+// ParseOCPITariffRestrictions does the opposite of GetOCPITariffRestrictions, returning the
+// opening hours during which a tariff element with the given restrictions applies. Restrictions
+// without day_of_week apply on every day and restrictions without start_time or end_time apply
+// from the start or until the end of the day. When end_time is before start_time, the
+// restriction runs past midnight into the next day.
+func ParseOCPITariffRestrictions(restrictions []OCPITariffRestrictions) ([]OpeningHours, error) {
+	var intervals []weekInterval
+	for i, r := range restrictions {
+		begin, end := 0, 1440
+		if r.StartTime != "" {
+			v, err := parseClockTime(r.StartTime)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tariff restrictions #%d: %s", i+1, err)
+			}
+			if v == 1440 {
+				return nil, fmt.Errorf("invalid tariff restrictions #%d: invalid start time `24:00`: expected to be before 24:00", i+1)
+			}
+			begin = v
+		}
+		if r.EndTime != "" && r.EndTime != "00:00" {
+			v, err := parseClockTime(r.EndTime)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tariff restrictions #%d: %s", i+1, err)
+			}
+			end = v
+		}
+		if end <= begin {
+			end += 1440
+		}
+
+		days := r.DayOfWeek
+		if len(days) == 0 {
+			days = ocpiDaysOfWeek[:]
+		}
+		for _, d := range days {
+			weekday := slices.Index(ocpiDaysOfWeek[:], d)
+			if weekday < 0 {
+				return nil, fmt.Errorf("invalid tariff restrictions #%d: invalid day of week `%s`: expected to be MONDAY to SUNDAY", i+1, d)
+			}
+
+			midnight := time.Duration(weekday) * day
+			intervals = append(intervals, weekInterval{
+				start: midnight + time.Duration(begin)*time.Minute,
+				end:   midnight + time.Duration(end)*time.Minute,
+			})
+		}
+	}
+
+	return openingHoursFromIntervals(intervals), nil
+}
@@ -0,0 +1,171 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOCPITariffRestrictions(t *testing.T) {
+	tests := map[string]struct {
+		openingHours   []OpeningHours
+		expectedResult []OCPITariffRestrictions
+		expectedError  error
+	}{
+		"when off-peak on weekday nights": {
+			openingHours: mustParseOpeningHours("W1T22:00:00/W2T07:00:00,W2T22:00:00/W3T07:00:00,W3T22:00:00/W4T07:00:00,W4T22:00:00/W5T07:00:00,W5T22:00:00/W6T07:00:00"),
+			expectedResult: []OCPITariffRestrictions{
+				{StartTime: "22:00", EndTime: "00:00", DayOfWeek: []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}},
+				{StartTime: "00:00", EndTime: "07:00", DayOfWeek: []string{"TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}},
+			},
+		},
+		"when off-peak on weekday nights and weekends": {
+			openingHours: mustParseOpeningHours("W1T22:00:00/W2T07:00:00,W2T22:00:00/W3T07:00:00,W3T22:00:00/W4T07:00:00,W4T22:00:00/W5T07:00:00,W5T22:00:00/W1T07:00:00"),
+			expectedResult: []OCPITariffRestrictions{
+				{StartTime: "00:00", EndTime: "07:00", DayOfWeek: []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}},
+				{StartTime: "22:00", EndTime: "00:00", DayOfWeek: []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}},
+				{DayOfWeek: []string{"SATURDAY", "SUNDAY"}},
+			},
+		},
+		"when several windows per day": {
+			openingHours: mustParseOpeningHours("W1T07:00:00/W1T09:00:00,W1T17:00:00/W1T19:00:00,W3T07:00:00/W3T09:00:00"),
+			expectedResult: []OCPITariffRestrictions{
+				{StartTime: "07:00", EndTime: "09:00", DayOfWeek: []string{"MONDAY", "WEDNESDAY"}},
+				{StartTime: "17:00", EndTime: "19:00", DayOfWeek: []string{"MONDAY"}},
+			},
+		},
+		"when 24/7": {
+			openingHours: []OpeningHours{TwentyFourSevenOH},
+			expectedResult: []OCPITariffRestrictions{
+				{DayOfWeek: []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}},
+			},
+		},
+		"when seconds": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 3, MinutesSinceMidnight: 480, Seconds: 30},
+				Close: &TimeInWeek{Weekday: 3, MinutesSinceMidnight: 960, Seconds: 15},
+			}},
+			expectedResult: []OCPITariffRestrictions{
+				{StartTime: "08:00", EndTime: "16:01", DayOfWeek: []string{"WEDNESDAY"}},
+			},
+		},
+		"when never open": {
+			openingHours:   []OpeningHours{},
+			expectedResult: []OCPITariffRestrictions{},
+		},
+		"when invalid": {
+			openingHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 8, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 960},
+			}},
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday `8`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GetOCPITariffRestrictions(tt.openingHours)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestParseOCPITariffRestrictions(t *testing.T) {
+	tests := map[string]struct {
+		restrictions   []OCPITariffRestrictions
+		expectedResult string
+		expectedError  error
+	}{
+		"when split at midnight": {
+			restrictions: []OCPITariffRestrictions{
+				{StartTime: "22:00", EndTime: "00:00", DayOfWeek: []string{"MONDAY", "TUESDAY"}},
+				{StartTime: "00:00", EndTime: "07:00", DayOfWeek: []string{"TUESDAY", "WEDNESDAY"}},
+			},
+			expectedResult: "W1T22:00:00/W2T07:00:00,W2T22:00:00/W3T07:00:00",
+		},
+		"when end time before start time": {
+			restrictions: []OCPITariffRestrictions{
+				{StartTime: "22:00", EndTime: "07:00", DayOfWeek: []string{"FRIDAY", "SUNDAY"}},
+			},
+			expectedResult: "W5T22:00:00/W6T07:00:00,W7T22:00:00/W1T07:00:00",
+		},
+		"when only days": {
+			restrictions: []OCPITariffRestrictions{
+				{DayOfWeek: []string{"SATURDAY", "SUNDAY"}},
+			},
+			expectedResult: "W6T00:00:00/W7T24:00:00",
+		},
+		"when only times": {
+			restrictions: []OCPITariffRestrictions{
+				{StartTime: "09:00"},
+			},
+			expectedResult: "W1T09:00:00/W1T24:00:00,W2T09:00:00/W2T24:00:00,W3T09:00:00/W3T24:00:00,W4T09:00:00/W4T24:00:00,W5T09:00:00/W5T24:00:00,W6T09:00:00/W6T24:00:00,W7T09:00:00/W7T24:00:00",
+		},
+		"when without restrictions": {
+			restrictions:   []OCPITariffRestrictions{{}},
+			expectedResult: TwentyFourSevenString,
+		},
+		"when day of week invalid": {
+			restrictions: []OCPITariffRestrictions{
+				{DayOfWeek: []string{"MONDAY"}},
+				{DayOfWeek: []string{"Tuesday"}},
+			},
+			expectedError: fmt.Errorf("invalid tariff restrictions #2: invalid day of week `Tuesday`: expected to be MONDAY to SUNDAY"),
+		},
+		"when start time invalid": {
+			restrictions: []OCPITariffRestrictions{
+				{StartTime: "7:00", EndTime: "09:00"},
+			},
+			expectedError: fmt.Errorf("invalid tariff restrictions #1: invalid time `7:00`: expected to be formatted as hh:mm"),
+		},
+		"when start time at end of day": {
+			restrictions: []OCPITariffRestrictions{
+				{StartTime: "24:00", EndTime: "07:00"},
+			},
+			expectedError: fmt.Errorf("invalid tariff restrictions #1: invalid start time `24:00`: expected to be before 24:00"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseOCPITariffRestrictions(tt.restrictions)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+		})
+	}
+}
+
+func TestOCPITariffRestrictionsJSON(t *testing.T) {
+	t.Parallel()
+
+	ohs := mustParseOpeningHours("W1T22:00:00/W2T07:00:00,W6T00:00:00/W7T24:00:00")
+	restrictions, err := GetOCPITariffRestrictions(ohs)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(restrictions)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"start_time": "22:00", "end_time": "00:00", "day_of_week": ["MONDAY"]},
+		{"start_time": "00:00", "end_time": "07:00", "day_of_week": ["TUESDAY"]},
+		{"day_of_week": ["SATURDAY", "SUNDAY"]}
+	]`, string(data))
+
+	var parsed []OCPITariffRestrictions
+	assert.NoError(t, json.Unmarshal(data, &parsed))
+	result, err := ParseOCPITariffRestrictions(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, OpeningHoursSliceToString(ohs), OpeningHoursSliceToString(result))
+}
@@ -0,0 +1,267 @@
+package openinghours
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// weekInterval is a half-open interval [start, end) within a week, given as the time elapsed since
+// monday 00:00.
+type weekInterval struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// offset returns the time elapsed between monday 00:00 and the given time in the week.
+func (tiw TimeInWeek) offset() time.Duration {
+	return time.Duration(tiw.Weekday-1)*day +
+		time.Duration(tiw.MinutesSinceMidnight)*time.Minute +
+		time.Duration(tiw.Seconds)*time.Second
+}
+
+// bounds returns the opening and closing times of open-ended opening hours, see OpeningHours. The
+// third return value is false when neither is set.
+func (oh OpeningHours) bounds() (TimeInWeek, TimeInWeek, bool) {
+	if oh.Open == nil && oh.Close == nil {
+		return TimeInWeek{}, TimeInWeek{}, false
+	}
+
+	open := TimeInWeek{Weekday: 1, MinutesSinceMidnight: 0}
+	if oh.Open != nil {
+		open = *oh.Open
+	}
+
+	close := TimeInWeek{Weekday: 7, MinutesSinceMidnight: 1440}
+	if oh.Close != nil {
+		close = *oh.Close
+	}
+
+	return open, close, true
+}
+
+// interval returns the interval covered by the opening hours. When the closing time is before the
+// opening time, the opening hours wrap around the end of the week and the returned end lies beyond
+// the end of the week. The second return value is false when the opening hours are empty or
+// invalid.
+func (oh OpeningHours) interval() (weekInterval, bool) {
+	open, close, ok := oh.bounds()
+	if !ok || open.validate() != nil || close.validate() != nil {
+		return weekInterval{}, false
+	}
+
+	start, end := open.offset()%week, close.offset()
+	if end == start {
+		return weekInterval{}, false
+	}
+	if end < start {
+		end += week
+	}
+
+	return weekInterval{start: start, end: end}, true
+}
+
+// weekIntervals returns the intervals in the week during which the opening hours are open, sorted
+// and merged so that none of them overlap or touch each other. Ranges wrapping around the end of
+// the week are split in two.
+func weekIntervals(ohs []OpeningHours) []weekInterval {
+	intervals := make([]weekInterval, 0, len(ohs))
+	for _, oh := range ohs {
+		if wi, ok := oh.interval(); ok {
+			intervals = append(intervals, wi)
+		}
+	}
+
+	return normalizeIntervals(intervals)
+}
+
+// normalizeIntervals splits the intervals wrapping around the end of the week in two, then sorts
+// and merges them so that none of them overlap or touch each other.
+func normalizeIntervals(intervals []weekInterval) []weekInterval {
+	split := make([]weekInterval, 0, len(intervals)+1)
+	for _, wi := range intervals {
+		if wi.end > week {
+			split = append(split, weekInterval{start: 0, end: wi.end - week})
+			wi.end = week
+		}
+		split = append(split, wi)
+	}
+
+	slices.SortFunc(split, func(a, b weekInterval) int {
+		return cmp.Compare(a.start, b.start)
+	})
+
+	merged := split[:0]
+	for _, wi := range split {
+		if n := len(merged); n > 0 && wi.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, wi.end)
+			continue
+		}
+		merged = append(merged, wi)
+	}
+
+	return merged
+}
+
+// openingHoursFromIntervals does the opposite of weekIntervals. The intervals at the start and at
+// the end of the week are joined into a single range wrapping around the end of the week.
+func openingHoursFromIntervals(intervals []weekInterval) []OpeningHours {
+	intervals = normalizeIntervals(intervals)
+
+	if n := len(intervals); n > 1 && intervals[0].start == 0 && intervals[n-1].end == week {
+		intervals[n-1].end = week + intervals[0].end
+		intervals = intervals[1:]
+	}
+
+	ohs := make([]OpeningHours, 0, len(intervals))
+	for _, wi := range intervals {
+		end := wi.end
+		if end > week {
+			end -= week
+		}
+
+		open, close := timeInWeekAt(wi.start, false), timeInWeekAt(end, true)
+		ohs = append(ohs, OpeningHours{Open: &open, Close: &close})
+	}
+
+	return ohs
+}
+
+// dayPeriod is a half-open period [start, end) within a day, given as the time elapsed since
+// midnight.
+type dayPeriod struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// dailyPeriods returns the periods during which the opening hours are open on each day of the
+// week, monday first. The periods are sorted and ranges running past midnight are split at
+// midnight.
+func dailyPeriods(ohs []OpeningHours) [7][]dayPeriod {
+	var days [7][]dayPeriod
+	for _, wi := range weekIntervals(ohs) {
+		for weekday, p := range wi.days() {
+			days[weekday] = append(days[weekday], p)
+		}
+	}
+
+	return days
+}
+
+// days splits the interval at midnight, yielding the day of the week, monday being 0, and the
+// period of each day it covers in chronological order. Intervals wrapping around the end of the
+// week continue on monday.
+func (wi weekInterval) days() iter.Seq2[int, dayPeriod] {
+	return func(yield func(int, dayPeriod) bool) {
+		for start := wi.start; start < wi.end; {
+			midnight := start.Truncate(day)
+			end := min(wi.end, midnight+day)
+			if !yield(int(midnight/day)%7, dayPeriod{start: start - midnight, end: end - midnight}) {
+				return
+			}
+			start = end
+		}
+	}
+}
+
+// minutes returns the start and end of the period in minutes since midnight. The start is rounded
+// down and the end is rounded up, so that the period is never shortened.
+func (p dayPeriod) minutes() (int, int) {
+	start := int(p.start / time.Minute)
+	end := int((p.end + time.Minute - 1) / time.Minute)
+
+	return start, end
+}
+
+// timeOfDay formats the time elapsed since midnight as hh:mm:ss, midnight at the end of the day
+// being 24:00:00.
+func timeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second))
+}
+
+// timeInWeekAt returns the time in the week at the given offset since monday 00:00. Midnight is
+// given as 24:00 of the previous day when closing, and as 00:00 otherwise.
+func timeInWeekAt(offset time.Duration, closing bool) TimeInWeek {
+	days := int(offset / day)
+	rem := offset % day
+	if closing && rem == 0 && days > 0 {
+		days--
+		rem = day
+	}
+
+	return TimeInWeek{
+		Weekday:              days + 1,
+		MinutesSinceMidnight: int(rem / time.Minute),
+		Seconds:              int(rem % time.Minute / time.Second),
+	}
+}
+
+// startOfWeek returns the monday of the week containing t in the given location. The result is a
+// wall clock time, see inLocation.
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	weekday := (int(t.Weekday()) + 6) % 7
+
+	return time.Date(t.Year(), t.Month(), t.Day()-weekday, 0, 0, 0, 0, time.UTC)
+}
+
+// wallClock returns the wall clock time shown by the clocks in the given location at instant t. It
+// does the opposite of inLocation.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// wallOffset returns the time elapsed between the given monday and the wall clock time shown by the
+// clocks in the given location at instant t.
+func wallOffset(t time.Time, loc *time.Location, monday time.Time) time.Duration {
+	return wallClock(t, loc).Sub(monday)
+}
+
+// inLocation returns the instant at which the clocks in the given location show the wall clock
+// time. Wall clock times are carried in UTC, so that durations can be added to them without
+// having to take time zone transitions into account.
+//
+// Around daylight saving time transitions, the wall clock time is mapped to the first instant at
+// which the clocks show that time or a later one:
+//   - a wall clock time skipped when the clocks go forward maps to the instant of the transition,
+//     eg. 02:30 maps to 03:00 when the clocks jump from 02:00 to 03:00; and
+//   - a wall clock time occurring twice when the clocks go back maps to its first occurrence, eg.
+//     01:30 maps to 01:30 EDT rather than 01:30 EST.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+
+	_, before := t.Add(-day).Zone()
+	_, after := t.Add(day).Zone()
+	if before == after {
+		return t
+	}
+
+	var first time.Time
+	for _, offset := range []int{before, after} {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if _, o := candidate.Zone(); o != offset {
+			continue
+		}
+		if first.IsZero() || candidate.Before(first) {
+			first = candidate
+		}
+	}
+	if !first.IsZero() {
+		return first
+	}
+
+	// The wall clock time was skipped, the transition happened after the instant it would have
+	// had with the previous offset.
+	transition, _ := wall.Add(-time.Duration(before) * time.Second).In(loc).ZoneBounds()
+
+	return transition
+}
@@ -0,0 +1,203 @@
+package openinghours
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+func mustParseOpeningHours(v string) []OpeningHours {
+	ohs, err := ParseOpeningHours(v)
+	if err != nil {
+		panic(err)
+	}
+	return ohs
+}
+
+func TestInLocation(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	newYork := mustLoadLocation("America/New_York")
+
+	tests := map[string]struct {
+		wall           time.Time
+		loc            *time.Location
+		expectedResult time.Time
+	}{
+		"when regular day": {
+			wall:           time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC),
+		},
+		"when before spring forward in europe": {
+			wall:           time.Date(2026, 3, 29, 1, 59, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 3, 29, 0, 59, 0, 0, time.UTC),
+		},
+		"when skipped by spring forward in europe": {
+			wall:           time.Date(2026, 3, 29, 2, 30, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC),
+		},
+		"when after spring forward in europe": {
+			wall:           time.Date(2026, 3, 29, 3, 0, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC),
+		},
+		"when repeated by fall back in europe": {
+			wall:           time.Date(2026, 10, 25, 2, 30, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+		},
+		"when after fall back in europe": {
+			wall:           time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC),
+			loc:            amsterdam,
+			expectedResult: time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC),
+		},
+		"when skipped by spring forward in america": {
+			wall:           time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC),
+			loc:            newYork,
+			expectedResult: time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC),
+		},
+		"when repeated by fall back in america": {
+			wall:           time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC),
+			loc:            newYork,
+			expectedResult: time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC),
+		},
+		"when at fall back in america": {
+			wall:           time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC),
+			loc:            newYork,
+			expectedResult: time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := inLocation(tt.wall, tt.loc)
+			assert.Equal(t, tt.expectedResult, result.UTC())
+		})
+	}
+}
+
+func TestOccurrencesAroundDaylightSavingTime(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	newYork := mustLoadLocation("America/New_York")
+
+	tests := map[string]struct {
+		openingHours     string
+		loc              *time.Location
+		from             time.Time
+		expectedResult   []Interval
+		expectedDuration time.Duration
+	}{
+		"when spring forward in europe shortens the day": {
+			openingHours: "W7T00:00:00/W7T24:00:00",
+			loc:          amsterdam,
+			from:         time.Date(2026, 3, 23, 0, 0, 0, 0, amsterdam),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 3, 28, 23, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 3, 29, 22, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: 23 * time.Hour,
+		},
+		"when opening time skipped in europe": {
+			openingHours: "W7T02:30:00/W7T04:00:00",
+			loc:          amsterdam,
+			from:         time.Date(2026, 3, 23, 0, 0, 0, 0, amsterdam),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: time.Hour,
+		},
+		"when range entirely skipped in europe": {
+			openingHours:   "W7T02:00:00/W7T02:30:00",
+			loc:            amsterdam,
+			from:           time.Date(2026, 3, 23, 0, 0, 0, 0, amsterdam),
+			expectedResult: nil,
+		},
+		"when fall back in europe lengthens the day": {
+			openingHours: "W7T00:00:00/W7T24:00:00",
+			loc:          amsterdam,
+			from:         time.Date(2026, 10, 19, 0, 0, 0, 0, amsterdam),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: 25 * time.Hour,
+		},
+		"when closing time repeated in europe": {
+			openingHours: "W7T01:00:00/W7T02:30:00",
+			loc:          amsterdam,
+			from:         time.Date(2026, 10, 19, 0, 0, 0, 0, amsterdam),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 10, 24, 23, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+			}},
+			expectedDuration: 90 * time.Minute,
+		},
+		"when spring forward in america": {
+			openingHours: "W7T01:00:00/W7T03:00:00",
+			loc:          newYork,
+			from:         time.Date(2026, 3, 2, 0, 0, 0, 0, newYork),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 3, 8, 6, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: time.Hour,
+		},
+		"when fall back in america": {
+			openingHours: "W7T01:00:00/W7T03:00:00",
+			loc:          newYork,
+			from:         time.Date(2026, 10, 26, 0, 0, 0, 0, newYork),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 11, 1, 5, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: 3 * time.Hour,
+		},
+		"when overnight range across fall back in america": {
+			openingHours: "W6T22:00:00/W7T06:00:00",
+			loc:          newYork,
+			from:         time.Date(2026, 10, 26, 0, 0, 0, 0, newYork),
+			expectedResult: []Interval{{
+				Start: time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 11, 1, 11, 0, 0, 0, time.UTC),
+			}},
+			expectedDuration: 9 * time.Hour,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result := slices.Collect(Occurrences(ohs, tt.from, tt.from.AddDate(0, 0, 7), tt.loc))
+			assert.Len(t, result, len(tt.expectedResult))
+			for i := range min(len(result), len(tt.expectedResult)) {
+				assert.Equal(t, tt.expectedResult[i].Start, result[i].Start.UTC())
+				assert.Equal(t, tt.expectedResult[i].End, result[i].End.UTC())
+				assert.Equal(t, tt.expectedDuration, result[i].Duration())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package openinghours
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// zoneSuffix separates the opening hours from the time zone in the string representation of a
+// ZonedSchedule.
+const zoneSuffix = ";tz="
+
+// ZonedSchedule contains opening hours along with the time zone in which they apply.
+type ZonedSchedule struct {
+	Hours []OpeningHours
+
+	// Location is the time zone of the opening hours. When nil, the time zone is unknown and UTC
+	// is assumed.
+	Location *time.Location
+}
+
+// String returns the opening hours followed by the IANA name of the time zone, eg.
+// "W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam". The time zone is left out when unknown.
+func (zs ZonedSchedule) String() string {
+	if zs.Location == nil {
+		return OpeningHoursSliceToString(zs.Hours)
+	}
+
+	return OpeningHoursSliceToString(zs.Hours) + zoneSuffix + zs.Location.String()
+}
+
+// ParseZonedSchedule does the opposite of ZonedSchedule.String method. The time zone is optional,
+// so that any string accepted by ParseOpeningHours is accepted as well.
+func ParseZonedSchedule(v string) (ZonedSchedule, error) {
+	var loc *time.Location
+	if i := strings.LastIndex(v, zoneSuffix); i >= 0 {
+		name := v[i+len(zoneSuffix):]
+
+		// time.LoadLocation returns UTC for an empty name.
+		var err error
+		loc, err = time.LoadLocation(name)
+		if err != nil || name == "" {
+			return ZonedSchedule{}, fmt.Errorf("invalid time zone `%s`", name)
+		}
+
+		v = v[:i]
+	}
+
+	ohs, err := ParseOpeningHours(v)
+	if err != nil {
+		return ZonedSchedule{}, err
+	}
+
+	return ZonedSchedule{Hours: ohs, Location: loc}, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (zs ZonedSchedule) MarshalText() ([]byte, error) {
+	return []byte(zs.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (zs *ZonedSchedule) UnmarshalText(data []byte) error {
+	parsed, err := ParseZonedSchedule(string(data))
+	if err != nil {
+		return err
+	}
+
+	*zs = parsed
+	return nil
+}
+
+// Occurrences is like the Occurrences function, in the time zone of the schedule.
+func (zs ZonedSchedule) Occurrences(from, to time.Time) iter.Seq[Interval] {
+	return Occurrences(zs.Hours, from, to, zs.location())
+}
+
+// Status is like the Status function, in the time zone of the schedule.
+func (zs ZonedSchedule) Status(t time.Time, thresholds Thresholds) (OpenStatus, error) {
+	return Status(zs.Hours, t, zs.location(), thresholds)
+}
+
+// AddOpenDuration is like the AddOpenDuration function, in the time zone of the schedule.
+func (zs ZonedSchedule) AddOpenDuration(start time.Time, d time.Duration) (time.Time, error) {
+	return AddOpenDuration(zs.Hours, start, d, zs.location())
+}
+
+// OpenDurationBetween is like the OpenDurationBetween function, in the time zone of the schedule.
+func (zs ZonedSchedule) OpenDurationBetween(a, b time.Time) (time.Duration, error) {
+	return OpenDurationBetween(zs.Hours, a, b, zs.location())
+}
+
+func (zs ZonedSchedule) location() *time.Location {
+	if zs.Location == nil {
+		return time.UTC
+	}
+
+	return zs.Location
+}
+
+// locationName returns the name of the location, or an empty string when it is unknown.
+func locationName(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+
+	return loc.String()
+}
+
+// ConvertTimeZone converts opening hours from one time zone to another, eg. opening hours from
+// 08:00 to 18:00 in Europe/Amsterdam become opening hours from 06:00 to 16:00 in UTC during summer
+// time. Ranges are split and wrapped around day and week boundaries as needed.
+//
+// As the offset between both time zones may change during the year, the conversion is done for the
+// week containing the given reference time, in the target time zone. The second return value
+// reports whether the conversion differs for any other week in the year following it, in which
+// case the result is only valid for the reference week.
+func ConvertTimeZone(ohs []OpeningHours, from, to *time.Location, reference time.Time) ([]OpeningHours, bool, error) {
+	if from == nil || to == nil {
+		return nil, false, fmt.Errorf("missing time zone")
+	}
+
+	for _, oh := range ohs {
+		if err := oh.Validate(); err != nil {
+			return nil, false, err
+		}
+	}
+
+	monday := startOfWeek(reference, to)
+	intervals := convertWeek(ohs, from, to, monday)
+
+	var dateDependent bool
+	for i := 1; i <= 52 && !dateDependent; i++ {
+		dateDependent = !slices.Equal(intervals, convertWeek(ohs, from, to, monday.AddDate(0, 0, 7*i)))
+	}
+
+	return openingHoursFromIntervals(intervals), dateDependent, nil
+}
+
+// convertWeek returns the intervals during which the opening hours, interpreted in the from time
+// zone, are open in the week starting at the given monday in the to time zone.
+func convertWeek(ohs []OpeningHours, from, to *time.Location, monday time.Time) []weekInterval {
+	var intervals []weekInterval
+	for period := range Occurrences(ohs, inLocation(monday, to), inLocation(monday.Add(week), to), from) {
+		wi := weekInterval{start: wallOffset(period.Start, to, monday), end: wallOffset(period.End, to, monday)}
+		if wi.end > wi.start {
+			intervals = append(intervals, wi)
+		}
+	}
+
+	return normalizeIntervals(intervals)
+}
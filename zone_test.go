@@ -0,0 +1,270 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonedScheduleString(t *testing.T) {
+	tests := map[string]struct {
+		schedule       ZonedSchedule
+		expectedResult string
+	}{
+		"when time zone specified": {
+			schedule: ZonedSchedule{
+				Hours: []OpeningHours{{
+					Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+					Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1080},
+				}},
+				Location: mustLoadLocation("Europe/Amsterdam"),
+			},
+			expectedResult: "W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam",
+		},
+		"when time zone is UTC": {
+			schedule: ZonedSchedule{
+				Hours:    []OpeningHours{TwentyFourSevenOH},
+				Location: time.UTC,
+			},
+			expectedResult: "W1T00:00:00/W7T24:00:00;tz=UTC",
+		},
+		"when time zone not specified": {
+			schedule: ZonedSchedule{
+				Hours: []OpeningHours{TwentyFourSevenOH},
+			},
+			expectedResult: "W1T00:00:00/W7T24:00:00",
+		},
+		"when opening hours are empty": {
+			schedule: ZonedSchedule{
+				Location: mustLoadLocation("America/New_York"),
+			},
+			expectedResult: ";tz=America/New_York",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := tt.schedule.String()
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestParseZonedSchedule(t *testing.T) {
+	tests := map[string]struct {
+		schedule         string
+		expectedHours    []OpeningHours
+		expectedLocation string
+		expectedError    error
+	}{
+		"when time zone specified": {
+			schedule: "W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam",
+			expectedHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1080},
+			}},
+			expectedLocation: "Europe/Amsterdam",
+		},
+		"when time zone not specified": {
+			schedule: "W1T08:00:00/W1T18:00:00",
+			expectedHours: []OpeningHours{{
+				Open:  &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 480},
+				Close: &TimeInWeek{Weekday: 1, MinutesSinceMidnight: 1080},
+			}},
+		},
+		"when opening hours are empty": {
+			schedule:         ";tz=UTC",
+			expectedHours:    []OpeningHours{},
+			expectedLocation: "UTC",
+		},
+		"when time zone invalid": {
+			schedule:      "W1T08:00:00/W1T18:00:00;tz=Europe/Atlantis",
+			expectedError: fmt.Errorf("invalid time zone `Europe/Atlantis`"),
+		},
+		"when time zone empty": {
+			schedule:      "W1T08:00:00/W1T18:00:00;tz=",
+			expectedError: fmt.Errorf("invalid time zone ``"),
+		},
+		"when opening hours invalid": {
+			schedule:      "W9T08:00:00/W1T18:00:00;tz=UTC",
+			expectedError: fmt.Errorf("invalid opening hours: invalid workday in `W9T08:00:00`: expected to be between 1 (monday) and 7 (sunday)"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseZonedSchedule(tt.schedule)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedHours, result.Hours)
+			if tt.expectedLocation == "" {
+				assert.Nil(t, result.Location)
+			} else {
+				assert.Equal(t, tt.expectedLocation, result.Location.String())
+			}
+		})
+	}
+}
+
+func TestZonedScheduleJSON(t *testing.T) {
+	t.Parallel()
+
+	var site struct {
+		Hours ZonedSchedule `json:"hours"`
+	}
+	err := json.Unmarshal([]byte(`{"hours":"W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam"}`), &site)
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Amsterdam", site.Hours.Location.String())
+
+	data, err := json.Marshal(site)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"hours":"W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam"}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"hours":"W1T08:00:00/W1T18:00:00;tz=Nowhere"}`), &site)
+	assert.Error(t, err)
+}
+
+func TestZonedScheduleUsesLocation(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseZonedSchedule("W1T08:00:00/W1T18:00:00;tz=Europe/Amsterdam")
+	assert.NoError(t, err)
+
+	at := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	status, err := schedule.Status(at, Thresholds{})
+	assert.NoError(t, err)
+	assert.Equal(t, StateOpen, status.State)
+	assert.Equal(t, 30*time.Minute, status.Remaining)
+
+	schedule.Location = nil
+	status, err = schedule.Status(at, Thresholds{})
+	assert.NoError(t, err)
+	assert.Equal(t, StateOpen, status.State)
+	assert.Equal(t, 150*time.Minute, status.Remaining)
+}
+
+func TestConvertTimeZone(t *testing.T) {
+	amsterdam := mustLoadLocation("Europe/Amsterdam")
+	newYork := mustLoadLocation("America/New_York")
+	kolkata := mustLoadLocation("Asia/Kolkata")
+	summer := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2026, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		openingHours          string
+		from                  *time.Location
+		to                    *time.Location
+		reference             time.Time
+		expectedResult        string
+		expectedDateDependent bool
+		expectedError         error
+	}{
+		"when summer time to UTC": {
+			openingHours:          "W1T08:00:00/W1T18:00:00,W3T08:00:00/W3T18:00:00",
+			from:                  amsterdam,
+			to:                    time.UTC,
+			reference:             summer,
+			expectedResult:        "W1T06:00:00/W1T16:00:00,W3T06:00:00/W3T16:00:00",
+			expectedDateDependent: true,
+		},
+		"when winter time to UTC": {
+			openingHours:          "W1T08:00:00/W1T18:00:00,W3T08:00:00/W3T18:00:00",
+			from:                  amsterdam,
+			to:                    time.UTC,
+			reference:             winter,
+			expectedResult:        "W1T07:00:00/W1T17:00:00,W3T07:00:00/W3T17:00:00",
+			expectedDateDependent: true,
+		},
+		"when split across days": {
+			openingHours:          "W3T01:00:00/W3T05:00:00",
+			from:                  amsterdam,
+			to:                    time.UTC,
+			reference:             summer,
+			expectedResult:        "W2T23:00:00/W3T03:00:00",
+			expectedDateDependent: true,
+		},
+		"when wrapped around the end of the week": {
+			openingHours:          "W1T01:00:00/W1T05:00:00",
+			from:                  amsterdam,
+			to:                    time.UTC,
+			reference:             summer,
+			expectedResult:        "W7T23:00:00/W1T03:00:00",
+			expectedDateDependent: true,
+		},
+		"when wrapped around the end of the week the other way": {
+			openingHours:          "W7T20:00:00/W7T24:00:00",
+			from:                  time.UTC,
+			to:                    kolkata,
+			reference:             summer,
+			expectedResult:        "W1T01:30:00/W1T05:30:00",
+			expectedDateDependent: false,
+		},
+		"when between zones with the same rules": {
+			openingHours:          "W1T08:00:00/W1T18:00:00",
+			from:                  amsterdam,
+			to:                    mustLoadLocation("Europe/Berlin"),
+			reference:             summer,
+			expectedResult:        "W1T08:00:00/W1T18:00:00",
+			expectedDateDependent: false,
+		},
+		"when between zones changing clocks on different dates": {
+			openingHours:          "W1T08:00:00/W1T18:00:00",
+			from:                  amsterdam,
+			to:                    newYork,
+			reference:             summer,
+			expectedResult:        "W1T02:00:00/W1T12:00:00",
+			expectedDateDependent: true,
+		},
+		"when 24/7": {
+			openingHours:          TwentyFourSevenString,
+			from:                  amsterdam,
+			to:                    newYork,
+			reference:             time.Date(2026, 10, 26, 12, 0, 0, 0, time.UTC),
+			expectedResult:        TwentyFourSevenString,
+			expectedDateDependent: false,
+		},
+		"when never open": {
+			openingHours:          "",
+			from:                  amsterdam,
+			to:                    time.UTC,
+			reference:             summer,
+			expectedResult:        "",
+			expectedDateDependent: false,
+		},
+		"when time zone missing": {
+			openingHours:  "W1T08:00:00/W1T18:00:00",
+			from:          amsterdam,
+			to:            nil,
+			reference:     summer,
+			expectedError: fmt.Errorf("missing time zone"),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ohs, err := ParseOpeningHours(tt.openingHours)
+			assert.NoError(t, err)
+
+			result, dateDependent, err := ConvertTimeZone(ohs, tt.from, tt.to, tt.reference)
+			assert.Equal(t, tt.expectedError, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedResult, OpeningHoursSliceToString(result))
+			}
+			assert.Equal(t, tt.expectedDateDependent, dateDependent)
+		})
+	}
+}